@@ -2,12 +2,13 @@ package androidapi
 
 import (
 	"bytes"
-	"io"
+	"context"
 	"strings"
+	"time"
 
-	"go.cs.palashbauri.in/pankti/evaluator"
 	"go.cs.palashbauri.in/pankti/lexer"
 	"go.cs.palashbauri.in/pankti/object"
+	"go.cs.palashbauri.in/pankti/pankti"
 	"go.cs.palashbauri.in/pankti/parser"
 )
 
@@ -19,36 +20,33 @@ func NewParser(l lexer.Lexer) parser.Parser {
 	return *parser.NewParser(&l)
 }
 
-func DoParse(i string) string {
-	l := lexer.NewLexer(i)
-	p := parser.NewParser(&l)
-	prog := p.ParseProg()
-
-	if len(p.GetErrors()) >= 1 {
-		tempErrs := []string{}
-
-		for _, item := range p.GetErrors() {
-			tempErrs = append(tempErrs, item.String())
-		}
-
-		return strings.Join(tempErrs, " \n")
-	}
-
-	env := object.NewEnvMap()
-	eh := object.ErrorHelper{Source: i}
-	printBuff := bytes.Buffer{}
-	evd := evaluator.Eval(prog, env, eh, &printBuff, true)
-	rd, err := io.ReadAll(&printBuff)
-
-	printValue := ""
+// programs are stopped after running this long or allocating this much
+// memory, so an endless loop does not freeze the app
+const (
+	runTimeout = 10 * time.Second
+	maxAlloc   = 256 << 20
+)
 
-	if err == nil {
-		printValue = string(rd[:])
+// DoParse runs the program i and returns its output. The program reads
+// its input from the lines of input
+func DoParse(i string, input string) string {
+
+	out := bytes.Buffer{}
+	it := pankti.New(
+		pankti.WithStdout(&out),
+		pankti.WithStderr(&out),
+		pankti.WithStdin(strings.NewReader(input)),
+		pankti.WithLimits(object.Limits{MaxAlloc: maxAlloc}),
+		pankti.WithPermissions(object.Permissions{FS: object.FSNone, NoOSInfo: true}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), runTimeout)
+	defer cancel()
+	evd, err := it.Run(ctx, i)
+
+	if err == nil && evd != nil {
+		out.WriteString(evd.Inspect())
 	}
 
-	if evd != nil {
-		return printValue + evd.Inspect()
-	} else {
-		return printValue
-	}
+	return out.String()
 }
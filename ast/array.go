@@ -8,6 +8,7 @@ import (
 
 // Arrays
 type ArrLit struct {
+	Spanned
 	Token token.Token
 	Elms  []Expr
 }
@@ -33,6 +34,7 @@ func (ar *ArrLit) String() string {
 // Index Expression -> ARRAY[123]
 
 type IndexExpr struct {
+	Spanned
 	Token token.Token
 	Left  Expr
 	Index Expr
@@ -49,3 +51,33 @@ func (ie *IndexExpr) String() string {
 	out.WriteString("])")
 	return out.String()
 }
+
+// Slice Expression -> ARRAY[1:3], ARRAY[:3] or ARRAY[1:]
+
+type SliceExpr struct {
+	Spanned
+	Token token.Token
+	Left  Expr
+	// nil when the slice starts at the beginning
+	Start Expr
+	// nil when the slice goes on to the end
+	End Expr
+}
+
+func (*SliceExpr) exprNode()           {}
+func (se *SliceExpr) TokenLit() string { return se.Token.Literal }
+func (se *SliceExpr) String() string {
+	var out bytes.Buffer
+	out.WriteString("(")
+	out.WriteString(se.Left.String())
+	out.WriteString("[")
+	if se.Start != nil {
+		out.WriteString(se.Start.String())
+	}
+	out.WriteString(":")
+	if se.End != nil {
+		out.WriteString(se.End.String())
+	}
+	out.WriteString("])")
+	return out.String()
+}
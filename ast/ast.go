@@ -8,8 +8,18 @@ import (
 type Node interface {
 	TokenLit() string
 	String() string
+	// the part of the source code the node was parsed from
+	Span() token.Span
 }
 
+// Spanned is embedded in every node to record its span
+type Spanned struct {
+	Loc token.Span
+}
+
+func (s *Spanned) Span() token.Span      { return s.Loc }
+func (s *Spanned) SetSpan(sp token.Span) { s.Loc = sp }
+
 type Stmt interface {
 	Node
 	stmtNode()
@@ -22,6 +32,7 @@ type Expr interface {
 
 // Program - main entry point
 type Program struct {
+	Spanned
 	Source string //Main Source code
 	Stmts  []Stmt //List of all statements
 }
@@ -49,6 +60,7 @@ func (p *Program) String() string {
 }
 
 type Comment struct {
+	Spanned
 	Token token.Token
 	Value string
 }
@@ -7,6 +7,7 @@ import (
 )
 
 type IfExpr struct {
+	Spanned
 	Token     token.Token
 	Cond      Expr
 	TrueBlock *BlockStmt
@@ -32,6 +33,7 @@ func (i *IfExpr) String() string {
 }
 
 type WhileExpr struct {
+	Spanned
 	Token     token.Token
 	Cond      Expr
 	StmtBlock *BlockStmt
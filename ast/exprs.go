@@ -9,6 +9,7 @@ import (
 // Prefix Expression
 
 type PrefixExpr struct {
+	Spanned
 	Token token.Token
 	Op    string
 	Right Expr
@@ -30,6 +31,7 @@ func (pref *PrefixExpr) String() string {
 //Infix Expression
 
 type InfixExpr struct {
+	Spanned
 	Token token.Token
 	Left  Expr
 	Op    token.Token
@@ -49,3 +51,30 @@ func (inf *InfixExpr) String() string {
 	return out.String()
 
 }
+
+// Concatenation Expression
+// example -> "নাম: {নাম}" joins "নাম: " with the value of নাম
+
+type ConcatExpr struct {
+	Spanned
+	Token token.Token
+	Parts []Expr
+}
+
+func (*ConcatExpr) exprNode()            {}
+func (con *ConcatExpr) TokenLit() string { return con.Token.Literal }
+func (con *ConcatExpr) String() string {
+
+	var out bytes.Buffer
+	out.WriteString("\"")
+	for _, part := range con.Parts {
+		if s, ok := part.(*StringLit); ok {
+			out.WriteString(s.Value)
+			continue
+		}
+		out.WriteString("{" + part.String() + "}")
+	}
+	out.WriteString("\"")
+	return out.String()
+
+}
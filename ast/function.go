@@ -9,10 +9,13 @@ import (
 )
 
 type FunctionLit struct {
+	Spanned
 	Token  token.Token // The 'fn' token
 	Params []*Identifier
 	Body   *BlockStmt
 	Name   string
+	// the `##` comment lines before the `dhori` defining the function
+	Doc string
 }
 
 func (*FunctionLit) exprNode()           {}
@@ -35,7 +38,18 @@ func (fl *FunctionLit) String() string {
 	return out.String()
 }
 
+// ParamNames returns the names of the parameters of the function
+func (fl *FunctionLit) ParamNames() []string {
+	names := []string{}
+	for _, p := range fl.Params {
+		names = append(names, p.Value)
+	}
+
+	return names
+}
+
 type CallExpr struct {
+	Spanned
 	Token token.Token // The '(' token
 	Func  Expr
 	// Identifier or FunctionLiteral
@@ -9,6 +9,7 @@ import (
 //Hash
 
 type HashLit struct {
+	Spanned
 	Token token.Token
 	Pairs map[Expr]Expr
 }
@@ -8,6 +8,7 @@ import (
 // ==================== Strings ===============================
 // example -> "hello world"
 type StringLit struct {
+	Spanned
 	Token token.Token
 	Value string
 }
@@ -20,6 +21,7 @@ func (s *StringLit) String() string   { return s.Token.Literal }
 
 // ================= Identifier ================================
 type Identifier struct {
+	Spanned
 	Token token.Token
 	IsMod bool
 	Value string
@@ -36,6 +38,7 @@ func (id *Identifier) String() string {
 }
 
 type IncludeId struct {
+	Spanned
 	Token token.Token
 	Value string
 }
@@ -47,6 +50,7 @@ func (ii *IncludeId) String() string {
 }
 
 type IncludeExpr struct {
+	Spanned
 	Token    token.Token
 	Filename Expr
 }
@@ -60,6 +64,7 @@ func (ix *IncludeExpr) String() string   { return ix.Token.Literal }
 // ================ Numbers ====================================
 // Examples -> 100 , 200 , 23.3
 type NumberLit struct {
+	Spanned
 	Token token.Token
 	Value number.Number
 	IsInt bool
@@ -74,6 +79,7 @@ func (nl *NumberLit) String() string   { return nl.Token.Literal }
 // ================= Boolean ==================================
 // Examples -> True , Sotto , False....
 type Boolean struct {
+	Spanned
 	Token token.Token
 	Value bool
 }
@@ -85,6 +91,7 @@ func (b *Boolean) String() string   { return b.Token.Literal }
 // ===========================================================
 
 type Break struct {
+	Spanned
 	Token token.Token
 	Value string
 }
@@ -9,9 +9,12 @@ import (
 // ====================== Let / Dhori Statment ========================
 // Example -> dhori age = 20
 type LetStmt struct {
+	Spanned
 	Token token.Token
 	Name  Identifier
 	Value Expr
+	// the `##` comment lines before the statement
+	Doc string
 }
 
 func (*LetStmt) stmtNode()            {}
@@ -39,6 +42,7 @@ func (lst *LetStmt) String() string {
 // ==================== Return / Ferau statement ============
 // Example -> return true
 type ReturnStmt struct {
+	Spanned
 	Token     token.Token
 	ReturnVal Expr
 }
@@ -64,6 +68,7 @@ func (r *ReturnStmt) String() string {
 // ================== Print / Show / Dekhau Statment ============
 // Example -> show("Hello World")
 type ShowStmt struct {
+	Spanned
 	Token token.Token
 	Value []Expr
 }
@@ -92,6 +97,7 @@ func (s *ShowStmt) String() string {
 // ===============================================================
 
 type IncludeStmt struct {
+	Spanned
 	Token    token.Token
 	Filename Expr
 }
@@ -101,6 +107,7 @@ func (is *IncludeStmt) TokenLit() string { return is.Token.Literal }
 func (is *IncludeStmt) String() string   { return is.Token.Literal }
 
 type BlockStmt struct {
+	Spanned
 	Token token.Token
 	Stmts []Stmt
 }
@@ -117,6 +124,7 @@ func (bs *BlockStmt) String() string {
 
 // Expression Statement
 type ExprStmt struct {
+	Spanned
 	Token token.Token
 	Expr  Expr
 }
@@ -0,0 +1,238 @@
+// Package bangla analyses Bengali text: it splits words into aksharas,
+// finds conjuncts (যুক্তাক্ষর) and the hasanta, works with the vowel
+// signs (কার) and tells the script of a text apart.
+//
+// Aksharas are the grapheme clusters of the grapheme package, which the
+// string functions use too, so that "ক্ষমা" is ক্ষ and মা everywhere
+package bangla
+
+import (
+	"strings"
+	"unicode"
+
+	"go.cs.palashbauri.in/pankti/grapheme"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	hasanta = '্'
+	zwj     = '\u200d'
+	zwnj    = '\u200c'
+)
+
+// IsVowelSign reports if r is a dependent vowel sign (কার) of the Bengali
+// script, such as া or ি
+func IsVowelSign(r rune) bool {
+	switch {
+	case r >= 'া' && r <= 'ৄ':
+		return true
+	case r == 'ে' || r == 'ৈ' || r == 'ো' || r == 'ৌ' || r == 'ৗ':
+		return true
+	case r == 'ৢ' || r == 'ৣ':
+		return true
+	}
+
+	return false
+}
+
+// Aksharas splits s into its aksharas, leaving out white space
+func Aksharas(s string) []string {
+	result := []string{}
+	for _, c := range grapheme.Clusters(s) {
+		if len(strings.TrimSpace(c)) > 0 {
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
+
+// IsConjunct reports if s has a conjunct, which is a consonant joined to
+// the next one by the hasanta, as in ক্ষ or র্ক. A ZWJ between the
+// consonant and the hasanta, as in "র\u200d্যাব", still makes a conjunct;
+// a ZWNJ there asks for the hasanta to be shown, so it does not
+func IsConjunct(s string) bool {
+	rs := []rune(s)
+	for i := 0; i+2 < len(rs); i++ {
+		if !grapheme.IsConsonant(rs[i]) {
+			continue
+		}
+
+		linker := i + 1
+		if rs[linker] == zwj {
+			linker++
+		}
+
+		if linker+1 >= len(rs) || !grapheme.IsLinker(rs[linker]) {
+			continue
+		}
+
+		next := linker + 1
+		if rs[next] == zwj && next+1 < len(rs) {
+			next++
+		}
+
+		if grapheme.IsConsonant(rs[next]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Conjuncts returns the aksharas of s which have a conjunct
+func Conjuncts(s string) []string {
+	result := []string{}
+	for _, a := range Aksharas(s) {
+		if IsConjunct(a) {
+			result = append(result, a)
+		}
+	}
+
+	return result
+}
+
+// HasHasanta reports if s has the hasanta, either in a conjunct or
+// written on its own as in ক্
+func HasHasanta(s string) bool {
+	return strings.ContainsRune(s, hasanta)
+}
+
+// VowelSigns returns the vowel signs of s in order; a sign written in two
+// parts, such as ে and া for ো, is returned as one
+func VowelSigns(s string) []string {
+	result := []string{}
+	for _, r := range norm.NFC.String(s) {
+		if IsVowelSign(r) {
+			result = append(result, string(r))
+		}
+	}
+
+	return result
+}
+
+// StripVowelSigns removes the vowel signs from s, leaving the letters
+// they were written with
+func StripVowelSigns(s string) string {
+	return strings.Map(func(r rune) rune {
+		if IsVowelSign(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// Script is the writing system of a text
+type Script string
+
+const (
+	Bengali Script = "bengali"
+	Latin   Script = "latin"
+	Mixed   Script = "mixed"
+	// text without letters, such as numbers and punctuation, or with
+	// letters of other scripts only
+	Other Script = "other"
+)
+
+// ScriptOf tells if the letters of s are Bengali, Latin or both. Digits,
+// marks and punctuation do not count
+func ScriptOf(s string) Script {
+	bengali, latin := false, false
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+
+		switch {
+		case unicode.Is(unicode.Bengali, r):
+			bengali = true
+		case unicode.Is(unicode.Latin, r):
+			latin = true
+		}
+	}
+
+	switch {
+	case bengali && latin:
+		return Mixed
+	case bengali:
+		return Bengali
+	case latin:
+		return Latin
+	}
+
+	return Other
+}
+
+// isWordRune reports if r can be part of a word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r) || r == zwj || r == zwnj
+}
+
+// Words returns the words of s, which are separated by white space and
+// punctuation. A full stop or comma between digits, as in ৩.৫০ or
+// 1,000, is part of the number
+func Words(s string) []string {
+	rs := []rune(s)
+	inNumber := func(i int) bool {
+		return (rs[i] == '.' || rs[i] == ',') && i > 0 && i+1 < len(rs) &&
+			unicode.IsDigit(rs[i-1]) && unicode.IsDigit(rs[i+1])
+	}
+
+	result := []string{}
+	start := -1
+	for i := 0; i <= len(rs); i++ {
+		if i < len(rs) && (isWordRune(rs[i]) || inNumber(i)) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+
+		if start >= 0 {
+			result = append(result, string(rs[start:i]))
+			start = -1
+		}
+	}
+
+	return result
+}
+
+// isSentenceEnd reports if the rune at i of rs ends a sentence: the
+// dari । and ॥, ? and !, or a full stop which is not in a number
+func isSentenceEnd(rs []rune, i int) bool {
+	switch rs[i] {
+	case '।', '॥', '?', '!':
+		return true
+	case '.':
+		return i+1 == len(rs) || !unicode.IsDigit(rs[i+1])
+	}
+
+	return false
+}
+
+// Sentences returns the sentences of s, each with its punctuation at
+// the end and without the white space around it. Text after the last
+// punctuation is a sentence too if it has a word
+func Sentences(s string) []string {
+	result := []string{}
+	rs := []rune(s)
+
+	start := 0
+	add := func(end int) {
+		sentence := strings.TrimSpace(string(rs[start:end]))
+		if len(Words(sentence)) > 0 {
+			result = append(result, sentence)
+		}
+		start = end
+	}
+
+	for i := range rs {
+		// a run of punctuation, such as ?! or ।।, ends the same sentence
+		if isSentenceEnd(rs, i) && (i+1 == len(rs) || !isSentenceEnd(rs, i+1)) {
+			add(i + 1)
+		}
+	}
+	add(len(rs))
+
+	return result
+}
@@ -0,0 +1,114 @@
+package bangla
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAksharas(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"ক্ষমা", []string{"ক্ষ", "মা"}},
+		{"বাংলা ভাষা", []string{"বাং", "লা", "ভা", "ষা"}},
+		{"স্বাস্থ্য", []string{"স্বা", "স্থ্য"}},
+		{"  ", []string{}},
+	}
+
+	for i, tt := range tests {
+		got := Aksharas(tt.input)
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("tests[%d] -> expected %q, got %q", i, tt.expected, got)
+		}
+	}
+}
+
+func TestConjuncts(t *testing.T) {
+	tests := []struct {
+		input    string
+		conjunct bool
+		hasanta  bool
+	}{
+		{"ক্ষ", true, true},
+		{"র্ক", true, true},
+		{"ক্\u200dষ", true, true},
+		{"ক্", false, true},
+		{"কা", false, false},
+		{"ক্\u200c", false, true},
+		{"র\u200d্যা", true, true},
+		{"র\u200c্য", false, true},
+	}
+
+	for i, tt := range tests {
+		if got := IsConjunct(tt.input); got != tt.conjunct {
+			t.Errorf("tests[%d] -> IsConjunct(%q) = %t", i, tt.input, got)
+		}
+
+		if got := HasHasanta(tt.input); got != tt.hasanta {
+			t.Errorf("tests[%d] -> HasHasanta(%q) = %t", i, tt.input, got)
+		}
+	}
+
+	got := Conjuncts("বিজ্ঞান ও প্রযুক্তি")
+	expected := []string{"জ্ঞা", "প্র", "ক্তি"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Conjuncts -> expected %q, got %q", expected, got)
+	}
+
+	got = Conjuncts("র\u200d্যাব")
+	expected = []string{"র\u200d্যা"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Conjuncts -> expected %q, got %q", expected, got)
+	}
+}
+
+func TestVowelSigns(t *testing.T) {
+	// ো written as ে and া is one sign
+	got := VowelSigns("কোকিল কো")
+	expected := []string{"ো", "ি", "ো"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("VowelSigns -> expected %q, got %q", expected, got)
+	}
+
+	if got := StripVowelSigns("বাংলা ভাষা"); got != "বংল ভষ" {
+		t.Errorf("StripVowelSigns -> got %q", got)
+	}
+}
+
+func TestScriptOf(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Script
+	}{
+		{"আমার সোনার বাংলা", Bengali},
+		{"hello, world", Latin},
+		{"আমি Go লিখি", Mixed},
+		{"১২৩ 456 ।", Other},
+		{"", Other},
+	}
+
+	for i, tt := range tests {
+		if got := ScriptOf(tt.input); got != tt.expected {
+			t.Errorf("tests[%d] -> expected %q, got %q", i, tt.expected, got)
+		}
+	}
+}
+
+func TestWordsAndSentences(t *testing.T) {
+	text := "আমি ভাত খাই। তুমি কি খাবে? দাম ৩.৫০ টাকা!!  আর কিছু"
+
+	words := Words(text)
+	if len(words) != 11 || words[7] != "৩.৫০" {
+		t.Errorf("Words -> got %q", words)
+	}
+
+	expected := []string{"আমি ভাত খাই।", "তুমি কি খাবে?", "দাম ৩.৫০ টাকা!!", "আর কিছু"}
+	if got := Sentences(text); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Sentences -> expected %q, got %q", expected, got)
+	}
+
+	if got := Sentences(" । "); len(got) != 0 {
+		t.Errorf("Sentences -> expected none, got %q", got)
+	}
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bytes"
 	"flag"
 	"fmt"
 	"os"
@@ -61,7 +60,7 @@ func main() {
 		env := object.NewEnvMap()
 		eh := object.ErrorHelper{Source: input}
 		start := time.Now()
-		result = evaluator.Eval(prog, env, eh, &bytes.Buffer{}, false)
+		result = evaluator.Eval(prog, env, eh)
 		duration = time.Since(start)
 
 	}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.cs.palashbauri.in/pankti/pankti"
+	"go.cs.palashbauri.in/pankti/token"
+
+	"github.com/spf13/cobra"
+)
+
+// docCmd represents the doc command
+var docCmd = &cobra.Command{
+	Use:   "doc [FILENAME] [NAME]",
+	Short: "Show the documentation of a Pankti Source File",
+	Long:  "Show the `##` comments written before the definitions of a pankti source file, or only the ones of NAME",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("please provide a file to document")
+		}
+
+		return nil
+	},
+
+	Run: func(cmd *cobra.Command, args []string) {
+		f, err := os.ReadFile(args[0])
+		if err != nil {
+			fmt.Printf("Cannot read `%s`\n\n", args[0])
+			os.Exit(1)
+		}
+
+		docs, err := pankti.Docs(string(f))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		found := false
+		for _, d := range docs {
+			if len(args) >= 2 && d.Name != token.Normalize(args[1]) {
+				continue
+			}
+
+			found = true
+			fmt.Println(d.Signature())
+			fmt.Println("    " + strings.ReplaceAll(d.Text, "\n", "\n    "))
+			fmt.Println()
+		}
+
+		if len(args) >= 2 && !found {
+			fmt.Printf("`%s` has no documentation in `%s`\n\n", args[1], args[0])
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(docCmd)
+}
@@ -1,22 +1,31 @@
 package cmd
 
 import (
-	"bytes"
+	"context"
 	"errors"
 	"fmt"
-	"io"
-
 	"os"
+	"strings"
 
-	"go.cs.palashbauri.in/pankti/evaluator"
-	"go.cs.palashbauri.in/pankti/lexer"
+	"go.cs.palashbauri.in/pankti/constants"
+	"go.cs.palashbauri.in/pankti/diag"
+	"go.cs.palashbauri.in/pankti/number"
 	"go.cs.palashbauri.in/pankti/object"
-	"go.cs.palashbauri.in/pankti/parser"
-	"go.cs.palashbauri.in/pankti/repl"
+	"go.cs.palashbauri.in/pankti/pack"
+	"go.cs.palashbauri.in/pankti/pankti"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	engineName string
+	perms      object.Permissions
+	readOnly   bool
+	noFS       bool
+	digitsName string
+	packName   string
+)
+
 // runCmd represents the run command
 var runCmd = &cobra.Command{
 	Use:   "run [FILENAME]",
@@ -45,54 +54,79 @@ var runCmd = &cobra.Command{
 				fmt.Printf("Cannot read `%s`\n\n", filename)
 			}
 
-			//fmt.Println(string(f))
-
-			lx := lexer.NewLexer(string(f))
-			/*
-			   for !lx.AtEOF(){
-			       nt := lx.NextToken()
-			       fmt.Printf(` Lit-> %s
-			                   Line -> %d
-			                   Col -> %d
-			                   Type -> %s
-
-			       ` + "\n" , nt.Literal , nt.LineNo , nt.Column , nt.Type)
-			   }*/
-			ps := parser.NewParser(&lx)
-			at := ps.ParseProg()
-
-			if len(ps.GetErrors()) != 0 {
-				repl.ShowParseErrors(os.Stdin, ps.GetErrors())
-				fmt.Printf("fix above mentioned errors first!\n\n")
-			} else {
-				env := object.NewEnvMap()
-				eh := object.ErrorHelper{Source: string(f)}
-				printBuff := bytes.Buffer{}
-
-				evd := evaluator.Eval(at, env, eh, &printBuff, false)
+			engine := pankti.Evaluator
+			switch engineName {
+			case "vm":
+				engine = pankti.VM
+			case "eval":
+			default:
+				fmt.Printf("Unknown engine `%s`\n\n", engineName)
+				os.Exit(1)
+			}
 
-				//rd, _ := ioutil.ReadAll(&printBuff)
-				rd, err := io.ReadAll(&printBuff)
+			digits, ok := number.ParseDigits(digitsName)
+			if !ok {
+				fmt.Printf("Unknown digits `%s`\n\n", digitsName)
+				os.Exit(1)
+			}
 
-				printValue := ""
+			kw, err := pack.Find(packName)
+			if err != nil {
+				fmt.Printf("%s\n\n", err)
+				os.Exit(1)
+			}
 
-				if err != nil {
-					printValue = string(rd[:])
-				}
-				fmt.Println(printValue)
+			switch {
+			case noFS:
+				perms.FS = object.FSNone
+			case readOnly:
+				perms.FS = object.FSReadOnly
+			}
 
-				if evd != nil {
-					fmt.Println(evd.Inspect())
-				}
+			it := pankti.New(
+				pankti.WithEngine(engine),
+				pankti.WithPermissions(perms),
+				pankti.WithColor(diag.UseColor(os.Stderr)),
+				pankti.WithDigits(digits),
+				pankti.WithPack(kw),
+			)
+			evd, err := it.Run(context.Background(), string(f))
+
+			var perr *pankti.ParseError
+			if errors.As(err, &perr) {
+				fmt.Printf("fix above mentioned errors first!\n\n")
 			}
 
-			//fmt.Println(args[0])
+			if err != nil {
+				os.Exit(1)
+			}
 
+			if evd != nil && evd.Type() != object.NULL_OBJ {
+				fmt.Println(evd.Inspect())
+			}
 		}
 	},
 }
 
 func init() {
+	runCmd.Flags().StringVarP(&engineName, "engine", "e", "eval", "engine used to run the program (eval or vm)")
+	runCmd.Flags().BoolVar(&readOnly, "read-only", false, "do not allow the program to change files")
+	runCmd.Flags().BoolVar(&noFS, "no-fs", false, "do not allow the program to use files")
+	runCmd.Flags().StringVar(&perms.FSRoot, "fs-root", "", "only allow the program to use files inside of this directory")
+	runCmd.Flags().BoolVar(&perms.NoOSInfo, "no-os-info", false, "do not allow the program to ask about the operating system and the user")
+	runCmd.Flags().StringVar(&digitsName, "digits", os.Getenv(constants.DIGITS_ENV), "digits used to show numbers (ascii or bengali); defaults to $"+constants.DIGITS_ENV)
+	runCmd.Flags().StringVar(&packName, "pack", packFromEnv(), "keyword pack of the program: a built in pack ("+strings.Join(pack.Names(), ", ")+") or a pack file; defaults to $"+constants.PACK_ENV)
+	runCmd.Flags().BoolVar(&perms.NoStdin, "no-stdin", false, "do not allow the program to read input")
 	rootCmd.AddCommand(runCmd)
 
 }
+
+// packFromEnv returns the keyword pack set in the environment, or the
+// default one
+func packFromEnv() string {
+	if name := os.Getenv(constants.PACK_ENV); len(name) > 0 {
+		return name
+	}
+
+	return pack.Default.Name
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+
+	"go.cs.palashbauri.in/pankti/stdlib"
+
+	"github.com/spf13/cobra"
+)
+
+// stdlibCmd represents the stdlib command
+var stdlibCmd = &cobra.Command{
+	Use:   "stdlib [MODULE]",
+	Short: "Show the functions of the standard library",
+	Long:  `Show the signatures of the builtin functions and the functions of standard library modules`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) >= 1 {
+			mod, ok := stdlib.GetModule(args[0])
+			if !ok {
+				fmt.Printf("Module `%s` does not exist!\n\n", args[0])
+				return
+			}
+
+			fmt.Print(mod.Help())
+			return
+		}
+
+		for _, b := range stdlib.Builtins {
+			fmt.Println(b.Builtin.Sig.String())
+		}
+
+		for _, mod := range stdlib.Modules() {
+			fmt.Printf("\n[%s]\n%s", mod.Name, mod.Help())
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(stdlibCmd)
+
+}
@@ -39,6 +39,9 @@ const (
 	OpClosure
 	OpGetFree
 	OpCurrentClosure
+	OpGetBuiltin
+	OpConcat
+	OpSlice
 )
 
 type Definition struct {
@@ -76,6 +79,9 @@ var definitions = map[OpCode]*Definition{
 	OpClosure:        {"OpClosure", []int{2, 1}},
 	OpGetFree:        {"OpGetFree", []int{1}},
 	OpCurrentClosure: {"OpCurrentClosure", []int{}},
+	OpGetBuiltin:     {"OpGetBuiltin", []int{1}},
+	OpConcat:         {"OpConcat", []int{2}},
+	OpSlice:          {"OpSlice", []int{}},
 }
 
 func (ins Instructions) String() string {
@@ -129,7 +135,7 @@ func ReadOperands(def *Definition, ins Instructions) ([]int, int) {
 		case 2:
 			operands[i] = int(ReadUint16(ins[offset:]))
 		case 1:
-			operands[i] = int(ReadUint16(ins[offset:1]))
+			operands[i] = int(ReadUint8(ins[offset:]))
 
 		}
 
@@ -0,0 +1,160 @@
+// Package collate orders text the way a Bengali dictionary does.
+//
+// Comparing strings by their code points puts the letters with a nukta
+// (such as ড় U+09DC and য় U+09DF) and the khanda ta ৎ after হ, and
+// Bengali digits after Latin letters. Here the letters follow the
+// alphabet, a consonant carries its inherent vowel অ unless a vowel sign
+// or the hasanta follows it, and the hasanta comes after all vowels, so
+// that the conjuncts of a consonant follow the consonant with every
+// vowel:
+//
+//	অ আ ই ঈ উ ঊ ঋ এ ঐ ও ঔ ং ঃ ঁ ক খ গ ... কা কি ... কৌ ক্ক ক্ষ খ ...
+//
+// Digits of either script have the same weight and come before letters,
+// and text in other scripts comes after Bengali in code point order
+package collate
+
+import (
+	"strings"
+)
+
+// the letters in the order of the alphabet; a vowel sign has the weight
+// of its vowel and the letters with a nukta follow the ones without it
+const (
+	vowels     = "অআইঈউঊঋৠঌৡএঐওঔ"
+	marks      = "ংঃঁ"
+	consonants = "কখগঘঙচছজঝঞটঠডড়ঢঢ়ণতৎথদধনপফবৱভমযয়রৰলশষসহ"
+)
+
+// vowelSigns are the dependent forms of the vowels after a consonant
+var vowelSigns = map[rune]rune{
+	'া': 'আ', 'ি': 'ই', 'ী': 'ঈ', 'ু': 'উ', 'ূ': 'ঊ', 'ৃ': 'ঋ', 'ৄ': 'ৠ',
+	'ৢ': 'ঌ', 'ৣ': 'ৡ', 'ে': 'এ', 'ৈ': 'ঐ', 'ো': 'ও', 'ৌ': 'ঔ',
+}
+
+// the letters which a nukta, when it is written separately, turns into
+// the letters with the nukta
+var nukta = map[rune]rune{'ড': 'ড়', 'ঢ': 'ঢ়', 'য': 'য়'}
+
+const (
+	hasanta  = '্'
+	nuktaMrk = '়'
+	khandaTa = 'ৎ'
+	zwnj     = '\u200c'
+	zwj      = '\u200d'
+)
+
+// the weights of the groups of characters; white space and the
+// punctuation before '0' weigh their code point
+const (
+	digitBase  = 0x40
+	letterBase = 0x100
+	otherBase  = 0x200
+)
+
+var weights = map[rune]int{}
+
+func init() {
+	w := letterBase
+	add := func(letters string) {
+		for _, r := range letters {
+			weights[r] = w
+			w++
+		}
+	}
+
+	add(vowels)
+	weights[hasanta] = w
+	w++
+	add(marks)
+	add(consonants)
+
+	for sign, vowel := range vowelSigns {
+		weights[sign] = weights[vowel]
+	}
+}
+
+func isConsonant(r rune) bool {
+	return strings.ContainsRune(consonants, r) && r != khandaTa
+}
+
+// Key returns the weights s is ordered by
+func Key(s string) []int {
+	rs := []rune(s)
+	key := make([]int, 0, len(rs))
+
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+
+		if i+1 < len(rs) && rs[i+1] == nuktaMrk {
+			if n, ok := nukta[r]; ok {
+				r = n
+				i++
+			}
+		}
+
+		switch {
+		case r == zwnj || r == zwj || r == 'ৗ' || r == nuktaMrk:
+			// joiners and marks which do not change the letter
+			continue
+		case r >= '0' && r <= '9':
+			key = append(key, digitBase+int(r-'0'))
+		case r >= '০' && r <= '৯':
+			key = append(key, digitBase+int(r-'০'))
+		case weights[r] > 0:
+			key = append(key, weights[r])
+		case r < '0':
+			key = append(key, int(r))
+		default:
+			key = append(key, otherBase+int(r))
+		}
+
+		if isConsonant(r) && !followedBySign(rs, i+1) {
+			key = append(key, weights['অ'])
+		}
+	}
+
+	return key
+}
+
+// followedBySign tells if the rune at i is a vowel sign or the hasanta,
+// which replace the inherent vowel of the consonant before it
+func followedBySign(rs []rune, i int) bool {
+	for ; i < len(rs); i++ {
+		switch rs[i] {
+		case nuktaMrk, zwnj, zwj:
+			continue
+		case hasanta:
+			return true
+		}
+
+		_, ok := vowelSigns[rs[i]]
+		return ok
+	}
+
+	return false
+}
+
+// Compare returns -1, 0 or 1 as a comes before, is the same as or comes
+// after b. Strings with the same weights are ordered by their code points
+func Compare(a, b string) int {
+	ka, kb := Key(a), Key(b)
+
+	for i := 0; i < len(ka) && i < len(kb); i++ {
+		if ka[i] != kb[i] {
+			if ka[i] < kb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case len(ka) < len(kb):
+		return -1
+	case len(ka) > len(kb):
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,73 @@
+package collate
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	// each word comes before the next one in a dictionary
+	ordered := []string{
+		"",
+		"১০",
+		"অ",
+		"অজগর",
+		"আম",
+		"ঈগল",
+		"ওষুধ",
+		"কংস",
+		"কথা",
+		"কল",
+		"কাক",
+		"কিছু",
+		"কৌতুক",
+		"ক্ষমা",
+		"খাতা",
+		"ডাক",
+		"ড়",
+		"ঢাক",
+		"তারা",
+		"থালা",
+		"যম",
+		"য়",
+		"রথ",
+		"হাত",
+		"apple",
+	}
+
+	for i := 0; i+1 < len(ordered); i++ {
+		a, b := ordered[i], ordered[i+1]
+		if Compare(a, b) >= 0 || Compare(b, a) <= 0 {
+			t.Errorf("expected %q before %q", a, b)
+		}
+	}
+
+	words := []string{"হাত", "অজগর", "ক্ষমা", "কাক", "য়", "কল"}
+	sort.Slice(words, func(i, j int) bool { return Compare(words[i], words[j]) < 0 })
+	expected := []string{"অজগর", "কল", "কাক", "ক্ষমা", "য়", "হাত"}
+	for i := range words {
+		if words[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, words)
+		}
+	}
+}
+
+func TestNuktaAndKhandaTa(t *testing.T) {
+	// the nukta written separately is the same letter
+	if !reflect.DeepEqual(Key("য\u09bcা"), Key("\u09dfা")) {
+		t.Errorf("য with a nukta should weigh the same as য়")
+	}
+
+	if Compare("উৎসব", "উত্তর") <= 0 {
+		t.Errorf("expected উত্তর before উৎসব")
+	}
+
+	if Compare("উৎসব", "উথলে") >= 0 {
+		t.Errorf("expected উৎসব before উথলে")
+	}
+
+	if Compare("১২", "12") == 0 || len(Key("১২")) != 2 || Key("১২")[0] != Key("12")[0] {
+		t.Errorf("digits of both scripts should weigh the same")
+	}
+}
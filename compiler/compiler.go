@@ -7,6 +7,8 @@ import (
 	"go.cs.palashbauri.in/pankti/ast"
 	"go.cs.palashbauri.in/pankti/code"
 	"go.cs.palashbauri.in/pankti/object"
+	"go.cs.palashbauri.in/pankti/stdlib"
+	"go.cs.palashbauri.in/pankti/token"
 )
 
 type Compiler struct {
@@ -20,17 +22,28 @@ type Compiler struct {
 
 	scopes     []CompScope
 	scopeIndex int
+
+	// name of the module being compiled for `anoyon`; its global names
+	// are defined as `modPrefix.name`
+	modPrefix string
+	rt        *object.Runtime
+
+	// shows the errors of the program with its source
+	eh object.ErrorHelper
 }
 
 type CompScope struct {
 	instructions code.Instructions
 	lastIns      EmittedIns
 	prevIns      EmittedIns
+	tokens       map[int]token.Token
 }
 
 type ByteCode struct {
 	Instructions code.Instructions
 	Constants    []object.Obj
+	// the source tokens of the instructions, as in object.CompiledFunc
+	Tokens map[int]token.Token
 }
 
 type EmittedIns struct {
@@ -43,11 +56,17 @@ func NewCompiler() *Compiler {
 		instructions: code.Instructions{},
 		lastIns:      EmittedIns{},
 		prevIns:      EmittedIns{},
+		tokens:       map[int]token.Token{},
+	}
+	symTable := NewSymbolTable()
+	for i, b := range stdlib.Builtins {
+		symTable.DefineBuiltin(i, b.Name)
 	}
+
 	return &Compiler{
 		instructions: code.Instructions{},
 		constants:    []object.Obj{},
-		symTable:     NewSymbolTable(),
+		symTable:     symTable,
 		scopes:       []CompScope{defScope},
 		scopeIndex:   0,
 	}
@@ -75,9 +94,9 @@ func (c *Compiler) Compile(node ast.Node) error {
 
 		switch node.Op {
 		case "!":
-			c.emit(code.OpBang)
+			c.emitAt(node.Token, code.OpBang)
 		case "-":
-			c.emit(code.OpMinus)
+			c.emitAt(node.Token, code.OpMinus)
 		default:
 			return fmt.Errorf("Unknown operator %s", node.Op)
 		}
@@ -92,7 +111,7 @@ func (c *Compiler) Compile(node ast.Node) error {
 
 			}
 
-			c.emit(code.OpGT)
+			c.emitAt(node.Op, code.OpGT)
 			return nil
 		}
 
@@ -105,19 +124,19 @@ func (c *Compiler) Compile(node ast.Node) error {
 		}
 		switch node.Op.Literal {
 		case "+":
-			c.emit(code.OpAdd)
+			c.emitAt(node.Op, code.OpAdd)
 		case "-":
-			c.emit(code.OpSub)
+			c.emitAt(node.Op, code.OpSub)
 		case "*":
-			c.emit(code.OpMul)
+			c.emitAt(node.Op, code.OpMul)
 		case "/":
-			c.emit(code.OpDiv)
+			c.emitAt(node.Op, code.OpDiv)
 		case ">":
-			c.emit(code.OpGT)
+			c.emitAt(node.Op, code.OpGT)
 		case "==":
-			c.emit(code.OpEqual)
+			c.emitAt(node.Op, code.OpEqual)
 		case "!=":
-			c.emit(code.OpNotEqual)
+			c.emitAt(node.Op, code.OpNotEqual)
 
 		default:
 			return fmt.Errorf("Unknown operator %s", node.Op.Literal)
@@ -166,17 +185,16 @@ func (c *Compiler) Compile(node ast.Node) error {
 		c.changeOperand(jmpPos, afterEBPos)
 
 	case *ast.LetStmt:
+		if inc, ok := node.Value.(*ast.IncludeExpr); ok {
+			return c.compileInclude(node.Name.Value, inc)
+		}
 
-		sm := c.symTable.Define(node.Name.Value)
+		sm := c.symTable.Define(c.prefixedName(node.Name.Value))
 		if err := c.Compile(node.Value); err != nil {
 			return err
 		}
 
-		if sm.Scope == GlobalScope {
-			c.emit(code.OpSetGlobal, sm.Index)
-		} else {
-			c.emit(code.OpSetLocal, sm.Index)
-		}
+		c.setSymbol(sm)
 		//c.emit(code.OpSetGlobal, sm.Index)
 	case *ast.BlockStmt:
 		for _, st := range node.Stmts {
@@ -185,9 +203,9 @@ func (c *Compiler) Compile(node ast.Node) error {
 			}
 		}
 	case *ast.Identifier:
-		s, ok := c.symTable.Resolve(node.Value)
+		s, ok := c.resolve(node.Value)
 		if !ok {
-			return fmt.Errorf("undefined variable %s", node.Value)
+			return object.NewErr(c.sourceToken(node.Token), &c.eh, true, "undefined variable %s", node.Value)
 		}
 		//	c.emit(code.OpGetGlobal, s.Index)
 		/*if s.Scope == GlobalScope {
@@ -216,6 +234,13 @@ func (c *Compiler) Compile(node ast.Node) error {
 	case *ast.StringLit:
 		str := &object.String{Value: node.Value}
 		c.emit(code.OpConstant, c.addConst(str))
+	case *ast.ConcatExpr:
+		for _, part := range node.Parts {
+			if err := c.Compile(part); err != nil {
+				return err
+			}
+		}
+		c.emitAt(node.Token, code.OpConcat, len(node.Parts))
 	case *ast.HashLit:
 		keys := []ast.Expr{}
 		for k := range node.Pairs {
@@ -239,7 +264,7 @@ func (c *Compiler) Compile(node ast.Node) error {
 
 		}
 
-		c.emit(code.OpHash, len(node.Pairs)*2)
+		c.emitAt(node.Token, code.OpHash, len(node.Pairs)*2)
 	case *ast.IndexExpr:
 		if err := c.Compile(node.Left); err != nil {
 			return err
@@ -248,7 +273,25 @@ func (c *Compiler) Compile(node ast.Node) error {
 		if err := c.Compile(node.Index); err != nil {
 			return err
 		}
-		c.emit(code.OpIndex)
+		c.emitAt(node.Token, code.OpIndex)
+
+	case *ast.SliceExpr:
+		if err := c.Compile(node.Left); err != nil {
+			return err
+		}
+
+		// a missing bound is left as null for the vm
+		for _, b := range []ast.Expr{node.Start, node.End} {
+			if b == nil {
+				c.emit(code.OpNull)
+				continue
+			}
+
+			if err := c.Compile(b); err != nil {
+				return err
+			}
+		}
+		c.emitAt(node.Token, code.OpSlice)
 
 	case *ast.FunctionLit:
 		c.enterScope()
@@ -273,13 +316,22 @@ func (c *Compiler) Compile(node ast.Node) error {
 		}
 		fs := c.symTable.FreeSymbols
 		nL := c.symTable.numDef
+		tokens := c.scopes[c.scopeIndex].tokens
 		ins := c.exitScope()
 
 		for _, s := range fs {
 			c.loadSymbol(s)
 		}
 
-		cFn := &object.CompiledFunc{Instructions: ins, NumLocals: nL, NumParams: len(node.Params)}
+		cFn := &object.CompiledFunc{
+			Instructions: ins,
+			NumLocals:    nL,
+			NumParams:    len(node.Params),
+			Name:         node.Name,
+			Params:       node.ParamNames(),
+			Doc:          node.Doc,
+			Tokens:       tokens,
+		}
 		fnIndex := c.addConst(cFn)
 		c.emit(code.OpClosure, fnIndex, len(fs))
 	case *ast.ReturnStmt:
@@ -296,12 +348,94 @@ func (c *Compiler) Compile(node ast.Node) error {
 				return err
 			}
 		}
-		c.emit(code.OpCall, len(node.Args))
+		c.emitAt(node.Token, code.OpCall, len(node.Args))
+	case *ast.ShowStmt:
+		// `dekhau` is compiled as a call to the builtin show function
+		s, _ := c.symTable.Resolve("dekhau")
+		c.loadSymbol(s)
+		for _, a := range node.Value {
+			if err := c.Compile(a); err != nil {
+				return err
+			}
+		}
+		c.emitAt(node.Token, code.OpCall, len(node.Value))
+		c.emit(code.OpPop)
+
+	}
+
+	return nil
+
+}
+
+// compileInclude compiles `dhori name = anoyon "module"` by defining
+// every member of the module as a variable called `name.member`
+func (c *Compiler) compileInclude(name string, inc *ast.IncludeExpr) error {
+	filename := inc.Filename.String()
+	var src string
+
+	if mod, ok := stdlib.FindModule(c.rt, filename); ok {
+		for _, member := range mod.Members() {
+			obj, _ := mod.Member(member)
+			sm := c.symTable.Define(name + "." + member)
+			c.emit(code.OpConstant, c.addConst(obj))
+			c.setSymbol(sm)
+		}
+		src = mod.Source
+	} else {
+		fdata, err := stdlib.LoadModuleSrc(c.rt, filename)
+		if err != nil {
+			return err
+		}
+		src = fdata
+	}
 
+	if len(src) > 0 {
+		// modules are read with the keyword pack of the program
+		prog, err := stdlib.ParseModule(c.rt, filename, src)
+		if err != nil {
+			return err
+		}
+
+		prevPrefix := c.modPrefix
+		c.modPrefix = name
+		err = c.Compile(prog)
+		c.modPrefix = prevPrefix
+
+		if err != nil {
+			return err
+		}
 	}
 
+	sm := c.symTable.Define(c.prefixedName(name))
+	c.emit(code.OpConstant, c.addConst(&object.String{Value: filename}))
+	c.setSymbol(sm)
 	return nil
+}
+
+// prefixedName gives the name a global definition gets while compiling
+// an included module
+func (c *Compiler) prefixedName(name string) string {
+	if len(c.modPrefix) > 0 && c.symTable.Outer == nil {
+		return c.modPrefix + "." + name
+	}
+	return name
+}
+
+func (c *Compiler) resolve(name string) (Symbol, bool) {
+	if len(c.modPrefix) > 0 {
+		if s, ok := c.symTable.Resolve(c.modPrefix + "." + name); ok {
+			return s, ok
+		}
+	}
+	return c.symTable.Resolve(name)
+}
 
+func (c *Compiler) setSymbol(s Symbol) {
+	if s.Scope == GlobalScope {
+		c.emit(code.OpSetGlobal, s.Index)
+	} else {
+		c.emit(code.OpSetLocal, s.Index)
+	}
 }
 
 func (c *Compiler) loadSymbol(s Symbol) {
@@ -314,6 +448,8 @@ func (c *Compiler) loadSymbol(s Symbol) {
 		c.emit(code.OpGetFree, s.Index)
 	case FuncScope:
 		c.emit(code.OpCurrentClosure)
+	case BuiltinScope:
+		c.emit(code.OpGetBuiltin, s.Index)
 	}
 }
 
@@ -362,6 +498,24 @@ func (c *Compiler) emit(op code.OpCode, oprs ...int) int {
 	return pos
 }
 
+// emitAt emits an instruction and records the token it was compiled
+// from, so that the vm can show where an error happened
+func (c *Compiler) emitAt(tok token.Token, op code.OpCode, oprs ...int) int {
+	pos := c.emit(op, oprs...)
+	c.scopes[c.scopeIndex].tokens[pos] = c.sourceToken(tok)
+	return pos
+}
+
+// sourceToken returns tok, or an empty token while compiling an included
+// module, whose source is not the one the errors are shown with
+func (c *Compiler) sourceToken(tok token.Token) token.Token {
+	if len(c.modPrefix) > 0 {
+		return token.Token{}
+	}
+
+	return tok
+}
+
 func (c *Compiler) setLastIns(op code.OpCode, pos int) {
 	prev := c.scopes[c.scopeIndex].lastIns
 	last := EmittedIns{OpCode: op, Pos: pos}
@@ -395,6 +549,7 @@ func (c *Compiler) enterScope() {
 		instructions: code.Instructions{},
 		lastIns:      EmittedIns{},
 		prevIns:      EmittedIns{},
+		tokens:       map[int]token.Token{},
 	}
 
 	c.symTable = NewEncolsedSymbolTable(c.symTable)
@@ -411,9 +566,39 @@ func (c *Compiler) exitScope() code.Instructions {
 	return ins
 }
 
+// NewCompilerWithState creates a compiler which continues with the
+// symbols and constants of an earlier compilation, for example in a REPL
+func NewCompilerWithState(s *SymbolTable, constants []object.Obj) *Compiler {
+	c := NewCompiler()
+	c.symTable = s
+	c.constants = constants
+	return c
+}
+
+// SymbolTable returns the symbol table of the global scope
+func (c *Compiler) SymbolTable() *SymbolTable {
+	return c.symTable
+}
+
+// SetRuntime sets the runtime whose host modules and module loader are
+// used for including modules which are not part of the standard library
+func (c *Compiler) SetRuntime(rt *object.Runtime) {
+	c.rt = rt
+	c.eh.Digits = rt.Digits
+	c.eh.Pack = rt.Pack
+}
+
+// SetSource sets the source code of the program, which the errors are
+// shown with; color colors them for a terminal
+func (c *Compiler) SetSource(src string, color bool) {
+	c.eh.Source = src
+	c.eh.Color = color
+}
+
 func (c *Compiler) ByteCode() *ByteCode {
 	return &ByteCode{
 		Instructions: c.currentIns(),
 		Constants:    c.constants,
+		Tokens:       c.scopes[c.scopeIndex].tokens,
 	}
 }
@@ -231,6 +231,20 @@ func TestIntArithmetic(t *testing.T) {
 				code.Make(code.OpPop),
 			},
 		},
+		{
+			input:   "[1,2,3][1:]",
+			exConst: []interface{}{1, 2, 3, 1},
+			exIns: []code.Instructions{
+				code.Make(code.OpConstant, 0),
+				code.Make(code.OpConstant, 1),
+				code.Make(code.OpConstant, 2),
+				code.Make(code.OpArray, 3),
+				code.Make(code.OpConstant, 3),
+				code.Make(code.OpNull),
+				code.Make(code.OpSlice),
+				code.Make(code.OpPop),
+			},
+		},
 		{
 			input: "ekti kaj() ferao(5+10) sesh",
 			exConst: []interface{}{5, 10, []code.Instructions{
@@ -3,10 +3,11 @@ package compiler
 type SymbolScope string
 
 const (
-	GlobalScope SymbolScope = "GLOBAL"
-	LocalScope  SymbolScope = "LOCAL"
-	FreeScope   SymbolScope = "FREE"
-	FuncScope   SymbolScope = "FUNCTION"
+	GlobalScope  SymbolScope = "GLOBAL"
+	LocalScope   SymbolScope = "LOCAL"
+	FreeScope    SymbolScope = "FREE"
+	FuncScope    SymbolScope = "FUNCTION"
+	BuiltinScope SymbolScope = "BUILTIN"
 )
 
 type Symbol struct {
@@ -54,6 +55,12 @@ func (s *SymbolTable) DefineFuncName(name string) Symbol {
 	return sm
 }
 
+func (s *SymbolTable) DefineBuiltin(index int, name string) Symbol {
+	sm := Symbol{Name: name, Index: index, Scope: BuiltinScope}
+	s.store[name] = sm
+	return sm
+}
+
 func (s *SymbolTable) defineFree(o Symbol) Symbol {
 	s.FreeSymbols = append(s.FreeSymbols, o)
 	sm := Symbol{Name: o.Name, Index: len(s.FreeSymbols) - 1}
@@ -70,7 +77,7 @@ func (s *SymbolTable) Resolve(n string) (Symbol, bool) {
 		if !ok {
 			return r, ok
 		}
-		if r.Scope == GlobalScope {
+		if r.Scope == GlobalScope || r.Scope == BuiltinScope {
 			return r, ok
 		}
 
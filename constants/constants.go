@@ -1,4 +1,10 @@
 package constants
 
 const IMPORT_PATH_ENV = "PANKTI_IMPORT"
+
+// DIGITS_ENV chooses the digits used to show numbers (ascii or bengali)
+const DIGITS_ENV = "PANKTI_DIGITS"
+
+// PACK_ENV chooses the keyword pack, by its name or the path of its file
+const PACK_ENV = "PANKTI_PACK"
 const DEBUG = true
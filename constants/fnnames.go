@@ -31,6 +31,7 @@ var TypeNames = map[string]string{
 	"NUM":        "সংখ্যা",
 	"INCLUDE":    "আনয়ন",
 	"SHOW":       "দেখাও",
+	"REGEX":      "রেজেক্স",
 }
 
 var UNKNOWN = "অজানা"
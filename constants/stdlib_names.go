@@ -10,6 +10,10 @@ func GetStdName(n string) (string, bool) {
 		"সাধারণ":  "std",
 		"স্ট্রিং": "string",
 		"সিস্টেম": "sys",
+		"রেজেক্স": "regex",
+		"জেসন":    "json",
+		"সিএসভি":  "csv",
+		"বাংলা":   "bangla",
 	}
 
 	val, ok := SL_BN_EN[n]
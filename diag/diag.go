@@ -0,0 +1,250 @@
+// Package diag renders error messages which point at the source code.
+//
+//	1 | dhori ক্ষমা = (১ + ২
+//	  |                   - এর পরে
+//	2 | dekhau(ক্ষমা)
+//	  | ^^^^^^
+//	এখানে `)` পাওয়া উচিত ছিল কিন্তু `dekhau` পাওয়া গেল
+//
+// Labels are positioned by grapheme clusters and display width, so they
+// line up under bengali letters with vowel signs and conjuncts.
+package diag
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"go.cs.palashbauri.in/pankti/errs"
+	"go.cs.palashbauri.in/pankti/grapheme"
+	"go.cs.palashbauri.in/pankti/token"
+)
+
+// Label marks a part of a line of the source code
+type Label struct {
+	// line number, starting from 1
+	Line int
+	// column of the first character in runes, starting from 1; with 0
+	// the line is shown without marking anything
+	Col int
+	// number of runes marked; at least one character is always marked,
+	// and ToLineEnd marks the rest of the line
+	Len int
+	Msg string
+}
+
+// ToLineEnd is the length of a label which marks a line up to its end
+const ToLineEnd = -1
+
+// At returns a label which marks the token t
+func At(t token.Token, msg string) Label {
+	if !t.Span.IsZero() {
+		return InSpan(t.Span, msg)
+	}
+
+	n := utf8.RuneCountInString(t.Literal)
+	if t.Type == token.STRING {
+		// the quotes are not part of the literal
+		n += 2
+	}
+
+	return Label{Line: t.LineNo, Col: t.Column, Len: n, Msg: msg}
+}
+
+// InSpan returns a label which marks sp; a span over several lines is
+// marked up to the end of its first line
+func InSpan(sp token.Span, msg string) Label {
+	n := sp.End.Column - sp.Start.Column
+	if sp.End.Line != sp.Start.Line {
+		n = ToLineEnd
+	}
+
+	return Label{Line: sp.Start.Line, Col: sp.Start.Column, Len: n, Msg: msg}
+}
+
+// Diagnostic is an error together with the places in the source code it
+// is about
+type Diagnostic struct {
+	Msg       string
+	Primary   Label
+	Secondary []Label
+	// a suggestion on how to fix the error, shown after the source
+	Hint string
+}
+
+// Renderer turns diagnostics into text
+type Renderer struct {
+	// use ANSI escape codes to color the output
+	Color bool
+	// the word shown before the hint of a diagnostic, in the language of
+	// the messages; the Bengali one if empty
+	HintPrefix string
+}
+
+const (
+	colorReset     = "\x1b[0m"
+	colorPrimary   = "\x1b[1;31m"
+	colorSecondary = "\x1b[1;34m"
+	colorGutter    = "\x1b[1;34m"
+	colorHint      = "\x1b[1;36m"
+	colorMsg       = "\x1b[1m"
+)
+
+func (r Renderer) paint(color, s string) string {
+	if !r.Color || len(s) == 0 {
+		return s
+	}
+
+	return color + s + colorReset
+}
+
+// Render returns the lines of src marked by the labels of d, followed by
+// the message and the hint of d. Labels outside of src are left out
+func (r Renderer) Render(src string, d Diagnostic) string {
+	out := strings.Builder{}
+	out.WriteString(r.Snippet(src, d))
+
+	if len(d.Msg) > 0 {
+		out.WriteString(r.paint(colorMsg, d.Msg) + "\n")
+	}
+
+	if len(d.Hint) > 0 {
+		prefix := r.HintPrefix
+		if len(prefix) == 0 {
+			prefix = errs.Errs["HINT_PREFIX"]
+		}
+
+		out.WriteString(r.paint(colorHint, prefix+": ") + d.Hint + "\n")
+	}
+
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+type mark struct {
+	Label
+	primary bool
+}
+
+// Snippet renders only the lines marked by the labels of d; every line of
+// the result ends with a newline
+func (r Renderer) Snippet(src string, d Diagnostic) string {
+	lines := strings.Split(src, "\n")
+
+	marks := []mark{}
+	for i, l := range append([]Label{d.Primary}, d.Secondary...) {
+		if l.Line >= 1 && l.Line <= len(lines) {
+			marks = append(marks, mark{Label: l, primary: i == 0})
+		}
+	}
+
+	// by line, and the primary label first within its line
+	sort.SliceStable(marks, func(i, j int) bool {
+		if marks[i].Line != marks[j].Line {
+			return marks[i].Line < marks[j].Line
+		}
+		return marks[i].primary && !marks[j].primary
+	})
+
+	gutter := 0
+	for _, m := range marks {
+		if w := len(strconv.Itoa(m.Line)); w > gutter {
+			gutter = w
+		}
+	}
+
+	out := strings.Builder{}
+	empty := r.paint(colorGutter, strings.Repeat(" ", gutter)+" |")
+
+	for i, m := range marks {
+		line := strings.TrimRight(lines[m.Line-1], "\r")
+
+		if i == 0 || marks[i-1].Line != m.Line {
+			num := fmt.Sprintf("%*d |", gutter, m.Line)
+			out.WriteString(r.paint(colorGutter, num) + " " + expandTabs(line) + "\n")
+		}
+
+		if m.Col == 0 {
+			continue
+		}
+
+		pad, width := measure(line, m.Col, m.Len)
+		char, color := "-", colorSecondary
+		if m.primary {
+			char, color = "^", colorPrimary
+		}
+
+		marker := strings.Repeat(char, width)
+		if len(m.Msg) > 0 {
+			marker += " " + m.Msg
+		}
+
+		out.WriteString(empty + " " + strings.Repeat(" ", pad) + r.paint(color, marker) + "\n")
+	}
+
+	return out.String()
+}
+
+// measure returns the display width of line before the character at the
+// rune column col, and of the n runes from there. Both ends are moved to
+// the edges of the grapheme clusters they fall in
+func measure(line string, col int, n int) (int, int) {
+	start := col - 1
+	if start < 0 {
+		start = 0
+	}
+	end := start + n
+	if n == ToLineEnd {
+		end = utf8.RuneCountInString(line)
+	}
+	if end <= start {
+		end = start + 1
+	}
+
+	pad, width := 0, 0
+	pos := 0
+	for _, c := range grapheme.Clusters(line) {
+		next := pos + utf8.RuneCountInString(c)
+		w := grapheme.ClusterWidth(c)
+
+		switch {
+		case next <= start:
+			pad += w
+		case pos < end:
+			width += w
+		}
+
+		pos = next
+	}
+
+	// a label after the end of the line, such as for a missing token
+	if start >= pos {
+		pad += start - pos
+	}
+	if width == 0 {
+		width = 1
+	}
+
+	return pad, width
+}
+
+func expandTabs(line string) string {
+	return strings.ReplaceAll(line, "\t", strings.Repeat(" ", grapheme.TabWidth))
+}
+
+// UseColor reports if output written to f should be colored: f must be a
+// terminal and the NO_COLOR environment variable must not be set
+func UseColor(f *os.File) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
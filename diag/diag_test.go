@@ -0,0 +1,92 @@
+package diag
+
+import (
+	"strings"
+	"testing"
+
+	"go.cs.palashbauri.in/pankti/token"
+)
+
+func TestRender(t *testing.T) {
+	src := "dhori ক্ষমা = ১\ndekhau(ক্ষমা + খাতা)"
+
+	tests := []struct {
+		d        Diagnostic
+		expected string
+	}{
+		{
+			Diagnostic{Msg: "বার্তা", Primary: Label{Line: 2, Col: 16, Len: 4}},
+			"2 | dekhau(ক্ষমা + খাতা)\n" +
+				"  |               ^^^^\n" +
+				"বার্তা",
+		},
+		// a label starting inside of a conjunct marks the whole conjunct
+		{
+			Diagnostic{Msg: "বার্তা", Primary: Label{Line: 1, Col: 8, Len: 1, Msg: "এখানে"}},
+			"1 | dhori ক্ষমা = ১\n" +
+				"  |       ^^ এখানে\n" +
+				"বার্তা",
+		},
+		{
+			Diagnostic{
+				Msg:       "বার্তা",
+				Primary:   At(token.Token{Literal: "ক্ষমা", LineNo: 2, Column: 8}, "এটা"),
+				Secondary: []Label{{Line: 1, Col: 7, Len: 5, Msg: "ওটা"}},
+				Hint:      "পরামর্শ",
+			},
+			"1 | dhori ক্ষমা = ১\n" +
+				"  |       ---- ওটা\n" +
+				"2 | dekhau(ক্ষমা + খাতা)\n" +
+				"  |        ^^^^ এটা\n" +
+				"বার্তা\n" +
+				"পরামর্শ: পরামর্শ",
+		},
+		{
+			Diagnostic{Msg: "বার্তা", Primary: InSpan(token.Span{
+				Start: token.Pos{Offset: 24, Line: 1, Column: 7},
+				End:   token.Pos{Offset: 42, Line: 2, Column: 3},
+			}, "")},
+			"1 | dhori ক্ষমা = ১\n" +
+				"  |       ^^^^^^^^\n" +
+				"বার্তা",
+		},
+		// a missing token after the end of the line
+		{
+			Diagnostic{Msg: "বার্তা", Primary: Label{Line: 1, Col: 16, Len: 1}},
+			"1 | dhori ক্ষমা = ১\n" +
+				"  |               ^\n" +
+				"বার্তা",
+		},
+		{
+			Diagnostic{Msg: "বার্তা", Primary: Label{Line: 1, Col: 0}},
+			"1 | dhori ক্ষমা = ১\n" +
+				"বার্তা",
+		},
+		{
+			Diagnostic{Msg: "বার্তা", Primary: Label{Line: 10, Col: 1}},
+			"বার্তা",
+		},
+	}
+
+	for i, tt := range tests {
+		got := Renderer{}.Render(src, tt.d)
+		if got != tt.expected {
+			t.Errorf("tests[%d] -> expected\n%s\ngot\n%s", i, tt.expected, got)
+		}
+	}
+}
+
+func TestRenderTabsAndColor(t *testing.T) {
+	d := Diagnostic{Msg: "বার্তা", Primary: Label{Line: 1, Col: 2, Len: 1}}
+
+	got := Renderer{}.Render("\tক", d)
+	expected := "1 |     ক\n  |     ^\nবার্তা"
+	if got != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, got)
+	}
+
+	colored := Renderer{Color: true}.Render("\tক", d)
+	if !strings.Contains(colored, colorPrimary+"^"+colorReset) {
+		t.Errorf("marker is not colored: %q", colored)
+	}
+}
@@ -7,10 +7,17 @@ import (
 )
 
 const (
-	NO_EKTI_BEFORE_FN   = "NO_EKTI_BEFORE_FN"
-	EXPECTED_GOT        = "EXPECTED_GOT"
-	NO_PREFIX_SUFFIX_FN = "NO_PREFIX_SUFFIX_FN"
-	INT_PARSE_ERR       = "INT_PARSE_ERR"
+	NO_EKTI_BEFORE_FN    = "NO_EKTI_BEFORE_FN"
+	EXPECTED_GOT         = "EXPECTED_GOT"
+	NO_PREFIX_SUFFIX_FN  = "NO_PREFIX_SUFFIX_FN"
+	INT_PARSE_ERR        = "INT_PARSE_ERR"
+	UNTERMINATED_STRING  = "UNTERMINATED_STRING"
+	INVALID_ESCAPE       = "INVALID_ESCAPE"
+	STRAY_BRACE          = "STRAY_BRACE"
+	NUMBER_INTERPOLATED  = "NUMBER_INTERPOLATED"
+	UNTERMINATED_COMMENT = "UNTERMINATED_COMMENT"
+	MALFORMED_NUMBER     = "MALFORMED_NUMBER"
+	UNKNOWN_PACK         = "UNKNOWN_PACK"
 )
 
 type ParserError interface {
@@ -19,6 +26,10 @@ type ParserError interface {
 	String() string
 }
 
+// The errors of the parser take their message from Msg, which is set to
+// the message of the keyword pack of the source; without it they use the
+// Bengali message
+
 type PeekError struct {
 	Msg      string
 	Expected token.TokenType
@@ -26,7 +37,7 @@ type PeekError struct {
 	ErrLine  string
 }
 
-func (*PeekError) GetMsg() string { return Errs[EXPECTED_GOT] }
+func (pe *PeekError) GetMsg() string { return msgOr(pe.Msg, EXPECTED_GOT) }
 
 func (pe *PeekError) GetToken() token.Token { return pe.Got }
 
@@ -39,13 +50,14 @@ func (pe *PeekError) String() string {
 }
 
 type NoPrefixSuffixError struct {
+	Msg     string
 	Token   token.Token
 	ErrLine string
 	//Type token.TokenType
 }
 
-func (*NoPrefixSuffixError) GetMsg() string {
-	return Errs[NO_PREFIX_SUFFIX_FN]
+func (spe *NoPrefixSuffixError) GetMsg() string {
+	return msgOr(spe.Msg, NO_PREFIX_SUFFIX_FN)
 }
 
 func (spe *NoPrefixSuffixError) GetToken() token.Token {
@@ -62,26 +74,64 @@ func (spe *NoPrefixSuffixError) String() string {
 }
 
 type NoEktiError struct {
+	Msg     string
 	Type    token.TokenType
 	ErrLine string
 }
 
-func (*NoEktiError) GetMsg() string { return Errs[NO_EKTI_BEFORE_FN] }
+func (nee *NoEktiError) GetMsg() string { return msgOr(nee.Msg, NO_EKTI_BEFORE_FN) }
 
 func (*NoEktiError) GetToken() token.Token { return token.Token{} }
 
 func (nee *NoEktiError) String() string {
 	return nee.ErrLine + "\n" + fmt.Sprintf(
-		Errs[NO_EKTI_BEFORE_FN],
+		nee.GetMsg(),
 		nee.Type,
 	)
 }
 
+// LexerError is a malformed part of the source code, such as a string
+// without its closing quote
+type LexerError struct {
+	Key     string
+	Msg     string
+	Token   token.Token
+	Args    []interface{}
+	ErrLine string
+}
+
+func (le *LexerError) GetMsg() string { return msgOr(le.Msg, le.Key) }
+
+func (le *LexerError) GetToken() token.Token { return le.Token }
+
+func (le *LexerError) String() string {
+	return le.ErrLine + "\n" + fmt.Sprintf(le.GetMsg(), le.Args...)
+}
+
+// Warning is source code which is valid but is likely not what was meant,
+// such as a number written in braces inside a string
+type Warning struct {
+	Key     string
+	Msg     string
+	Token   token.Token
+	Args    []interface{}
+	ErrLine string
+}
+
+func (w *Warning) GetMsg() string { return msgOr(w.Msg, w.Key) }
+
+func (w *Warning) GetToken() token.Token { return w.Token }
+
+func (w *Warning) String() string {
+	return w.ErrLine + "\n" + fmt.Sprintf(w.GetMsg(), w.Args...)
+}
+
 type IntegerParseError struct {
+	Msg   string
 	Token token.Token
 }
 
-func (*IntegerParseError) GetMsg() string { return Errs[INT_PARSE_ERR] }
+func (ipe *IntegerParseError) GetMsg() string { return msgOr(ipe.Msg, INT_PARSE_ERR) }
 
 func (ipe *IntegerParseError) GetToken() token.Token { return ipe.Token }
 
@@ -89,19 +139,36 @@ func (ipe *IntegerParseError) String() string {
 	return fmt.Sprintf(ipe.GetMsg(), ipe.GetToken())
 }
 
+func msgOr(msg, key string) string {
+	if len(msg) > 0 {
+		return msg
+	}
+
+	return Errs[key]
+}
+
 var Errs = map[string]string{
 
 	"NO_EKTI_BEFORE_FN":              "`কাজ`-এর আগে 'ekti' বা 'একটি' পাওয়া উচিত ছিল %s",
 	"EXPECTED_GOT":                   "এখানে `%s` পাওয়া উচিত ছিল কিন্তু `%s` পাওয়া গেল",
 	"NO_PREFIX_SUFFIX_FN":            "এটা %s নিয়ে কী করা উচিত আমি জানিনা",
 	"INT_PARSE_ERR":                  "%s - এই এটা তো একটা সংখ্যা নয়",
+	"UNTERMINATED_STRING":            "%d নং লাইনের %d নং অক্ষরে শুরু হওয়া লেখাটি শেষ হয়নি, শেষে '%s' দিতে হবে",
+	"INVALID_ESCAPE":                 "লেখার ভিতরে `%s` ব্যবহার করা যায় না; `\\n`, `\\t`, `\\\"`, `\\\\`, `\\{`, `\\}` অথবা `\\u{...}` ব্যবহার করুন",
+	"STRAY_BRACE":                    "লেখার ভিতরের এই `{` দিয়ে কোনো রাশি শুরু হয়নি; `{` লিখতে চাইলে `\\{` লিখুন, অথবা লেখাটি ব্যাকটিক (`) দিয়ে লিখুন",
+	"NUMBER_INTERPOLATED":            "সতর্কতা: লেখার ভিতরের {%s} শুধু %s সংখ্যাটি হয়ে যাবে; `{` ও `}` লিখতে চাইলে, যেমন রেজেক্সের পুনরাবৃত্তিতে, `\\{` লিখুন, অথবা লেখাটি ব্যাকটিক (`) দিয়ে লিখুন",
+	"UNTERMINATED_COMMENT":           "%d নং লাইনের %d নং অক্ষরে শুরু হওয়া মন্তব্যটি শেষ হয়নি, শেষে '*/' দিতে হবে",
+	"MALFORMED_NUMBER":               "`%s` সংখ্যাটি ঠিকভাবে লেখা হয়নি; এভাবে লিখুন: ১২৩, ১_০০_০০০, ১.৫e১০, 0x1F অথবা 0b1010",
+	"UNKNOWN_PACK":                   "'%s' নামে কোনো কীওয়ার্ড প্যাক নেই",
 	"FUN_CALL_NOT_ENOUGH_ARGS":       "এই '%s' কাজের জন্য %dটি চল রাশির প্রয়োজন কিন্তু পাওয়া গেলো %dটি",
+	"FUN_CALL_AT_LEAST_ARGS":         "এই '%s' কাজের জন্য অন্তত %dটি চল রাশির প্রয়োজন কিন্তু পাওয়া গেলো %dটি",
+	"ARG_TYPE_MISMATCH":              "এই '%s' কাজের '%s' চল রাশিকে %s হতে হবে কিন্তু পাওয়া গেলো %s",
 	"NOT_ALL_ARE_INT":                "এই '%s' কাজের সমস্ত জন্য দেওয়া সব চলরাশি গুলিকে সংখ্যা হতে হবে",
 	"NOT_ALL_ARE_LIST":               "এই কাজের জন্য প্রদত্ত সমস্ত চলরাশি গুলিকে 'তালিকা' হতে হবে।",
 	"INDEX_MUST_BE_NUMBER":           "এই কাজের জন্য সূচকটিকে সংখ্যা হতে হবে।",
 	"INDEX_OUT_RANGE":                "এই সূচকটি তালিকার আয়তনের থেকেও বড়।",
 	"FILENAME_MUST_BE_STRING":        "এখানে ফাইলের নাম একটি স্ট্রিং বা 'লেখা নাম' হতে হবে।",
-	"FAILED_TO_READ_FILE":            "এই ফাইলটি পড়া গেলো না।",
+	"FAILED_TO_READ_FILE":            "এই ফাইলটি পড়া গেলো না: %s",
 	"FAILED_TO_CREATE":               "এই ফাইলটি '%s' তৈরি করা গেল না!",
 	"FAILED_TO_CLOSE_FILE":           "এই ফাইলটি '%s' তৈরি করে খোলার পর আর বন্ধ করা গেল না।",
 	"FAILED_TO_WRITE_FILE":           "এই ফাইলটিতে '%s' লেখা গেল না।",
@@ -115,7 +182,7 @@ var Errs = map[string]string{
 	"FAILED_TO_WRITE_DATA":           "ফাইলে প্রদত্ত তথ্য সংরক্ষিত করা গেল না",
 	"TARGET_NO_DIR":                  "প্রদত্ত ঠিকানাটি কোন ফোল্ডার/ডাইরেক্টরি কে নির্দেশ করে না",
 	"TARGET_IS_DIR":                  "প্রদত্ত ঠিকানাটি কোন ফোল্ডার/ডাইরেক্টরি কে নির্দেশ করে",
-	"DIR_LIST_FAILED":                "ফোল্ডার/ডাইরেক্টরির ফাইলগুলির সূচি তৈরি করা গেল না।",
+	"DIR_LIST_FAILED":                "ফোল্ডার/ডাইরেক্টরির ফাইলগুলির সূচি তৈরি করা গেল না: %s",
 	"ARG_DECIMAL_PARSE_FAILED":       "কাজের জন্য প্রদত্ত চল রাশিগুলি দশমিক সংখ্যা হিসাবে গ্রহণ করা গেল না।",
 	"GCD_ALL_INT":                    "গসাগুর জন্য প্রদত্ত সমস্ত সংখ্যাগুলিকে পূর্ণসংখ্যা/Integer হতে হবে",
 	"SUM_ONLY_LISTS":                 "যোগফল শুধুমাত্র সংখ্যাযুক্ত তালিকারই বার করা সম্ভব",
@@ -126,6 +193,41 @@ var Errs = map[string]string{
 	"STRING_NUM_PARSE_FAIL_TEMPLATE": "প্রদত্ত চলরাশিকে স্ট্রিং/'লেখা' হিসাবে গ্রহণ করা গেল না।",
 	"CANNOT_PARSE_AS_NUM":            "প্রদত্ত চলরাশিকে সংখ্যাতে পরিণত করা যাবে না।",
 	"CANNOT_PARSE_STRING_AS_NUM":     "প্রদত্ত স্ট্রিং/'লেখা'কে সংখ্যাতে পরিণত করা যাবে না।",
+	"COUNT_MUST_BE_POSITIVE":         "এই কাজের জন্য সংখ্যাটিকে শূন্য বা তার বেশি পূর্ণসংখ্যা হতে হবে।",
+	"FORMAT_MISMATCH":                "ফরম্যাট '%s'-এর চিহ্নগুলির সাথে প্রদত্ত মানগুলি মেলেনি।",
+	"INVALID_REGEX":                  "রেজেক্স '%s' সঠিক নয়: %s",
+	"CALLBACK_NOT_CALLABLE":          "'%s' কাজটিকে এখান থেকে ডাকা যায় না।",
+	"JSON_DECODE_FAILED":             "JSON পড়া গেল না: %s",
+	"JSON_CANNOT_ENCODE":             "'%s' ধরনের মান JSON-এ লেখা যায় না।",
+	"JSON_DUPLICATE_KEY":             "হ্যাশের চাবি '%s' JSON-এ একাধিকবার লেখা হবে; যেমন 1 ও \"1\" দুটিই \"1\" হয়ে যায়।",
+	"JSON_UNKNOWN_OPTION":            "JSON লেখার '%s' বিকল্পটি অজানা; বিকল্পটি হল সুন্দর/pretty, যার মান সত্য বা মিথ্যা; হ্যাশের চাবিগুলি সবসময় সাজিয়ে লেখা হয়।",
+	"CSV_READ_FAILED":                "CSV পড়া গেল না: %s",
+	"CSV_BAD_ROWS":                   "CSV লেখার জন্য সমস্ত সারিকে তালিকা অথবা সমস্ত সারিকে অবিধান হতে হবে।",
+	"CSV_BAD_CELL":                   "'%s' ধরনের মান CSV-র ঘরে লেখা যায় না।",
+	"CSV_BAD_OPTION":                 "CSV-র '%s' বিকল্পটি অজানা বা তার মান সঠিক নয়।",
+	"SORT_NOT_COMPARABLE":            "'%s' ধরনের মান সাজানো যায় না; শুধু সংখ্যা ও স্ট্রিং নিজে থেকে সাজানো যায়, অন্যগুলির জন্য তুলনা করার কাজ দিন।",
+	"SORT_BAD_COMPARATOR":            "তুলনা করার কাজটিকে সত্য/মিথ্যা অথবা সংখ্যা ফেরত দিতে হবে, '%s' নয়।",
+	"SORT_BAD_OPTION":                "সাজানোর '%s' বিকল্পটি অজানা বা তার মান সঠিক নয়।",
 	"STDIN_READ_FAILED":              "Stdin থেকে তথ্য পড়া গেল না।",
+	"HOST_FUNC_FAILED":               "এই '%s' কাজটি করা গেল না: %s",
+	"HOST_VALUE_CONVERT":             "এই '%s' কাজের জন্য প্রদত্ত বা প্রাপ্ত মানটি ব্যবহার করা গেল না: %s",
+	"STEP_LIMIT_EXCEEDED":            "প্রোগ্রামটি নির্ধারিত %d ধাপের বেশি চলেছে, তাই থামানো হল।",
+	"TIMEOUT":                        "প্রোগ্রামটি নির্ধারিত সময়ের মধ্যে শেষ হয়নি, তাই থামানো হল।",
+	"CANCELLED":                      "প্রোগ্রামটি বাতিল করা হয়েছে।",
+	"CALL_DEPTH_EXCEEDED":            "কাজের ভিতরে কাজের ডাক নির্ধারিত %d স্তরের বেশি গভীর হয়ে গেছে।",
+	"ALLOC_LIMIT_EXCEEDED":           "প্রোগ্রামটি নির্ধারিত %d বাইটের বেশি মেমরি ব্যবহার করেছে, তাই থামানো হল।",
+	"INTERNAL_ERROR":                 "ইন্টারপ্রেটারে অভ্যন্তরীণ গোলযোগ: %v",
+	"PERM_NO_FS":                     "এই প্রোগ্রামের ফাইল ব্যবহার করার অনুমতি নেই।",
+	"PERM_READ_ONLY":                 "এই প্রোগ্রাম ফাইল শুধু পড়তে পারে, '%s' পরিবর্তন করার অনুমতি নেই।",
+	"PERM_OUTSIDE_ROOT":              "'%s' অনুমোদিত ফোল্ডারের বাইরে, তাই ব্যবহার করার অনুমতি নেই।",
+	"ROOT_NOT_CHANGEABLE":            "'%s' হল অনুমোদিত ফোল্ডারটি নিজেই, তাই এটি মোছা বা সরানো যাবে না।",
+	"PERM_NO_OS_INFO":                "এই প্রোগ্রামের অপারেটিং সিস্টেম ও ব্যবহারকারীর তথ্য জানার অনুমতি নেই।",
+	"PERM_NO_STDIN":                  "এই প্রোগ্রামের ইনপুট পড়ার অনুমতি নেই।",
+	"MODULE_NOT_FOUND":               "'%s' মডিউল বা ফাইল খুঁজে পাওয়া গেল না।",
+	"MODULE_PARSE_FAILED":            "'%s' মডিউলটি পড়া গেল না:\n%s",
+	"MODULE_RUN_FAILED":              "'%s' মডিউলটি চালানো গেল না:\n%s",
+	"FUN_DEFINED_HERE":               "কাজটি এখানে তৈরি করা হয়েছে",
+	"FUN_PARAMS_HINT":                "কাজটিকে এভাবে ডাকুন: %s%s",
+	"HINT_PREFIX":                    "পরামর্শ",
 	"NOT_ON_ANDROID":                 "এই কাজটি Android এ ব্যবহার করা যাবে না। ",
 }
@@ -1,8 +1,6 @@
 package evaluator
 
 import (
-	"bytes"
-
 	"go.cs.palashbauri.in/pankti/ast"
 	"go.cs.palashbauri.in/pankti/number"
 	"go.cs.palashbauri.in/pankti/object"
@@ -12,15 +10,13 @@ func evalHashLit(
 	node *ast.HashLit,
 	env *object.EnvMap,
 	eh *object.ErrorHelper,
-	printBuff *bytes.Buffer,
-	isGui bool,
 ) object.Obj {
 
 	pairs := make(map[object.HashKey]object.HashPair)
 
 	for kNode, vNode := range node.Pairs {
 
-		key := Eval(kNode, env, *eh, printBuff, isGui)
+		key := Eval(kNode, env, *eh)
 
 		if object.IsErr(key) {
 			return key
@@ -37,7 +33,7 @@ func evalHashLit(
 			)
 		}
 
-		val := Eval(vNode, env, *eh, printBuff, isGui)
+		val := Eval(vNode, env, *eh)
 
 		if object.IsErr(val) {
 			return val
@@ -56,6 +52,8 @@ func evalIndexExpr(left, index object.Obj, eh *object.ErrorHelper) object.Obj {
 	switch {
 	case left.Type() == object.ARRAY_OBJ && index.Type() == object.NUM_OBJ:
 		return evalArrIndexExpr(left, index, eh)
+	case left.Type() == object.STRING_OBJ && index.Type() == object.NUM_OBJ:
+		return evalStrIndexExpr(left, index, eh)
 	case left.Type() == object.HASH_OBJ:
 		return evalHashIndexExpr(left, index, eh)
 
@@ -117,3 +115,52 @@ func evalArrIndexExpr(arr, index object.Obj, eh *object.ErrorHelper) object.Obj
 
 	return arrObj.Elms[idx]
 }
+
+// evalStrIndexExpr returns the grapheme cluster of a string at an index
+func evalStrIndexExpr(str, index object.Obj, eh *object.ErrorHelper) object.Obj {
+	idx, ok := object.AsIndex(index)
+	if !ok {
+		return object.NewErr(index.GetToken(), eh, true, eh.Msg("INDEX_MUST_BE_NUMBER"))
+	}
+
+	if c, ok := object.ElementAt(str, idx); ok {
+		return c
+	}
+
+	return NULL
+}
+
+// evalSliceExpr returns a part of an array or a string; start and end
+// are nil for the missing bounds
+func evalSliceExpr(left, start, end object.Obj, eh *object.ErrorHelper) object.Obj {
+	if left.Type() != object.ARRAY_OBJ && left.Type() != object.STRING_OBJ {
+		return object.NewErr(left.GetToken(), eh, true, "Unsupported Slice Operator %s ", left.Type())
+	}
+
+	from, to, err := sliceBounds(start, end)
+	if err != nil {
+		return object.NewErr(err.GetToken(), eh, true, eh.Msg("INDEX_MUST_BE_NUMBER"))
+	}
+
+	return object.SliceOf(left, from, to)
+}
+
+// sliceBounds returns the integer values of the bounds of a slice, or
+// the bound which is not an integer
+func sliceBounds(start, end object.Obj) (*int64, *int64, object.Obj) {
+	bounds := [2]*int64{}
+
+	for i, b := range []object.Obj{start, end} {
+		if b == nil {
+			continue
+		}
+
+		v, ok := object.AsIndex(b)
+		if !ok {
+			return nil, nil, b
+		}
+		bounds[i] = &v
+	}
+
+	return bounds[0], bounds[1], nil
+}
@@ -1,8 +1,6 @@
 package evaluator
 
 import (
-	"bytes"
-
 	"go.cs.palashbauri.in/pankti/ast"
 	"go.cs.palashbauri.in/pankti/object"
 )
@@ -11,20 +9,18 @@ func evalIfExpr(
 	iex *ast.IfExpr,
 	env *object.EnvMap,
 	eh *object.ErrorHelper,
-	printBuff *bytes.Buffer,
-	isGui bool,
 ) object.Obj {
 
-	cond := Eval(iex.Cond, env, *eh, printBuff, isGui)
+	cond := Eval(iex.Cond, env, *eh)
 
 	if object.IsErr(cond) {
 		return cond
 	}
 
 	if isTruthy(cond) {
-		return Eval(iex.TrueBlock, env, *eh, printBuff, isGui)
+		return Eval(iex.TrueBlock, env, *eh)
 	} else if iex.ElseBlock != nil {
-		return Eval(iex.ElseBlock, env, *eh, printBuff, isGui)
+		return Eval(iex.ElseBlock, env, *eh)
 	} else {
 		return NULL
 	}
@@ -35,25 +31,36 @@ func evalWhileExpr(
 	wx *ast.WhileExpr,
 	env *object.EnvMap,
 	eh *object.ErrorHelper,
-	printBuff *bytes.Buffer,
-	isGui bool,
 ) object.Obj {
-	cond := Eval(wx.Cond, env, *eh, printBuff, isGui)
+	cond := Eval(wx.Cond, env, *eh)
 	var result object.Obj
 	if object.IsErr(cond) {
 		return cond
 	}
 
 	for isTruthy(cond) {
-		result = evalBlockStmt(wx.StmtBlock, env, eh, printBuff, isGui, true)
+		result = evalBlockStmt(wx.StmtBlock, env, eh, true)
 
-		if result != nil && result.Type() == object.BREAK_OBJ {
-			break
+		if result != nil {
+			rtype := result.Type()
+			if rtype == object.BREAK_OBJ {
+				break
+			}
+			if rtype == object.RETURN_VAL_OBJ || rtype == object.ERR_OBJ {
+				return result
+			}
 		}
 
 		//fmt.Printf("%v\n" , result)
 
-		cond = Eval(wx.Cond, env, *eh, printBuff, isGui)
+		cond = Eval(wx.Cond, env, *eh)
+		if object.IsErr(cond) {
+			return cond
+		}
+	}
+
+	if result == nil {
+		return NULL
 	}
 
 	if result.Type() == object.BREAK_OBJ {
@@ -1,8 +1,6 @@
 package evaluator
 
 import (
-	"bytes"
-
 	"go.cs.palashbauri.in/pankti/ast"
 	"go.cs.palashbauri.in/pankti/object"
 	"go.cs.palashbauri.in/pankti/token"
@@ -82,13 +80,11 @@ func evalExprs(
 	es []ast.Expr,
 	env *object.EnvMap,
 	eh *object.ErrorHelper,
-	printBuff *bytes.Buffer,
-	isGui bool,
 ) []object.Obj {
 	var res []object.Obj
 
 	for _, e := range es {
-		ev := Eval(e, env, *eh, printBuff, isGui)
+		ev := Eval(e, env, *eh)
 
 		if object.IsErr(ev) {
 			return []object.Obj{ev}
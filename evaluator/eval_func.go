@@ -1,9 +1,10 @@
 package evaluator
 
 import (
-	"bytes"
+	"fmt"
+	"strings"
 
-	"go.cs.palashbauri.in/pankti/errs"
+	"go.cs.palashbauri.in/pankti/diag"
 	"go.cs.palashbauri.in/pankti/object"
 	"go.cs.palashbauri.in/pankti/token"
 )
@@ -15,8 +16,6 @@ func applyFunc(
 	_ bool,
 	env *object.EnvMap,
 	eh *object.ErrorHelper,
-	printBuff *bytes.Buffer,
-	isGui bool,
 ) object.Obj {
 
 	//fmt.Println(caller)
@@ -24,27 +23,56 @@ func applyFunc(
 	switch fn := fn.(type) {
 	case *object.Function:
 		if len(fn.Params) == len(args) {
+			rt := env.GetRuntime()
+			if err := rt.EnterCall(); err != nil {
+				e := object.NewErr(caller, eh, false, "%s", err.Error())
+				e.Err = err
+				return e
+			}
+			defer rt.LeaveCall()
+
 			eEnv := extendFuncEnv(fn, args)
 
 			eX := object.NewEnvMap()
+			eX.Runtime = env.Runtime
+
+			// the modules brought in with anoyon stay usable in the body
+			for name, modEnv := range env.Envs {
+				eX.Envs[name] = modEnv
+			}
 
 			eX.Envs[object.DEFKEY] = *object.NewEnclosedEnv(eEnv)
 
-			evd := Eval(fn.Body, eX, *eh, printBuff, isGui)
+			evd := Eval(fn.Body, eX, *eh)
 			return unwrapReturnValue(evd)
 		} else {
 
-			return object.NewErr(caller, eh, false, errs.Errs["FUN_CALL_NOT_ENOUGH_ARGS"], fn.Name, len(fn.Params), len(args))
+			return object.NewDiagErr(eh, diag.Diagnostic{
+				Msg:       eh.Sprintf(eh.Msg("FUN_CALL_NOT_ENOUGH_ARGS"), fn.Name, len(fn.Params), len(args)),
+				Primary:   diag.At(caller, ""),
+				Secondary: []diag.Label{diag.At(fn.Token, eh.Msg("FUN_DEFINED_HERE"))},
+				Hint:      fmt.Sprintf(eh.Msg("FUN_PARAMS_HINT"), fn.Name, paramList(fn)),
+			})
 		}
 	case *object.Builtin:
 		//		fmt.Println(caller)
-		return fn.Fn(eh, env, caller, args...)
+		return allocated(fn.Call(eh, env, caller, args...), env)
 	default:
 		return object.NewBareErr("%s is not a function", fn.Type())
 
 	}
 }
 
+// paramList returns the names of the parameters of fn, such as `(ক, খ)`
+func paramList(fn *object.Function) string {
+	names := []string{}
+	for _, p := range fn.Params {
+		names = append(names, p.Value)
+	}
+
+	return "(" + strings.Join(names, ", ") + ")"
+}
+
 func extendFuncEnv(fn *object.Function, args []object.Obj) *object.Env {
 	env := object.NewEnclosedEnv(fn.Env)
 
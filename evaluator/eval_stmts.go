@@ -1,56 +1,26 @@
 package evaluator
 
 import (
-	"bytes"
 	"fmt"
-	"io"
-	"os"
 	"strings"
 
-	log "github.com/sirupsen/logrus"
 	"go.cs.palashbauri.in/pankti/ast"
 	"go.cs.palashbauri.in/pankti/object"
 )
 
 func evalShowStmt(
 	args []object.Obj,
-	printBuff *bytes.Buffer,
-	isGui bool,
+	env *object.EnvMap,
 ) object.Obj {
 
 	output := []string{}
 
 	for _, item := range args {
-		output = append(output, item.Inspect())
+		output = append(output, env.GetRuntime().Format(item))
 		//buff.Write([]byte(item.Inspect()))
 	}
 
-	if isGui {
-		oldStdout := os.Stdout
-		r, w, err := os.Pipe()
-		if err != nil {
-			log.Fatalf(err.Error())
-		}
-
-		os.Stdout = w
-
-		outC := make(chan string)
-
-		go func() {
-			var buf bytes.Buffer
-			io.Copy(&buf, r)
-			outC <- buf.String()
-		}()
-
-		fmt.Println(strings.Join(output, ""))
-		w.Close()
-		os.Stdout = oldStdout
-		out := <-outC
-
-		printBuff.Write([]byte(out))
-	} else {
-		fmt.Println(strings.Join(output, ""))
-	}
+	fmt.Fprintln(env.GetRuntime().Stdout, strings.Join(output, ""))
 
 	return NULL
 }
@@ -59,8 +29,6 @@ func evalBlockStmt(
 	block *ast.BlockStmt,
 	env *object.EnvMap,
 	eh *object.ErrorHelper,
-	printBuff *bytes.Buffer,
-	isGui bool,
 	isLoop bool,
 ) object.Obj {
 
@@ -69,7 +37,7 @@ func evalBlockStmt(
 
 	for _, stmt := range block.Stmts {
 		prevRes = res
-		res = Eval(stmt, env, *eh, printBuff, isGui)
+		res = Eval(stmt, env, *eh)
 
 		//fmt.Println("E_BS=> " , res)
 
@@ -1,16 +1,14 @@
 package evaluator
 
 import (
-	"bytes"
-	"fmt"
+	"errors"
 	"strings"
 
 	"go.cs.palashbauri.in/pankti/ast"
-	"go.cs.palashbauri.in/pankti/lexer"
 	"go.cs.palashbauri.in/pankti/number"
 	"go.cs.palashbauri.in/pankti/object"
-	"go.cs.palashbauri.in/pankti/parser"
 	"go.cs.palashbauri.in/pankti/stdlib"
+	"go.cs.palashbauri.in/pankti/token"
 )
 
 var (
@@ -23,15 +21,18 @@ func Eval(
 	node ast.Node,
 	env *object.EnvMap,
 	eh object.ErrorHelper,
-	printBuff *bytes.Buffer,
-	isGui bool,
 
 ) object.Obj {
+	// every node counts as a step for the limits of the runtime
+	if err := env.GetRuntime().Step(); err != nil {
+		return object.WrapErr(err)
+	}
+
 	switch node := node.(type) {
 	case *ast.Program: //Entry point of a Program AST
-		return evalProg(node, env, &eh, printBuff, isGui)
+		return evalProg(node, env, &eh)
 	case *ast.ExprStmt:
-		return Eval(node.Expr, env, eh, printBuff, isGui)
+		return Eval(node.Expr, env, eh)
 	case *ast.Boolean:
 		return getBoolObj(node.Value)
 	case *ast.NumberLit:
@@ -41,7 +42,7 @@ func Eval(
 		// Prefix ->
 		// <Operator> Expression
 		//
-		r := Eval(node.Right, env, eh, printBuff, isGui) // Evaluate the Expression to the smallest possible value
+		r := Eval(node.Right, env, eh) // Evaluate the Expression to the smallest possible value
 		if object.IsErr(r) {
 			return r
 		}
@@ -51,21 +52,21 @@ func Eval(
 		// Infix ->
 		// Left_Expression <Operator> Right_Expression
 		//
-		l := Eval(node.Left, env, eh, printBuff, isGui) // Evaluate the <Left_Expression> to the smallest possible value
+		l := Eval(node.Left, env, eh) // Evaluate the <Left_Expression> to the smallest possible value
 		if object.IsErr(l) {
 			return l
 		}
-		r := Eval(node.Right, env, eh, printBuff, isGui) // Evaluate the <Right_Expression> to the smallest possible value
+		r := Eval(node.Right, env, eh) // Evaluate the <Right_Expression> to the smallest possible value
 		if object.IsErr(r) {
 			return r
 		}
-		return evalInfixExpr(node.Op, l, r, &eh)
+		return allocated(evalInfixExpr(node.Op, l, r, &eh), env)
 	case *ast.IfExpr:
-		return evalIfExpr(node, env, &eh, printBuff, isGui)
+		return evalIfExpr(node, env, &eh)
 	case *ast.WhileExpr:
-		return evalWhileExpr(node, env, &eh, printBuff, isGui)
+		return evalWhileExpr(node, env, &eh)
 	case *ast.ReturnStmt:
-		val := Eval(node.ReturnVal, env, eh, printBuff, isGui)
+		val := Eval(node.ReturnVal, env, eh)
 		if object.IsErr(val) {
 			return val
 		}
@@ -73,14 +74,18 @@ func Eval(
 	case *ast.ShowStmt:
 		//
 		// Show / Print Statement
-		// here lies the real usage of `printBuff` and `isGui`
+		// output goes to the stdout of the runtime
 		//
-		args := evalExprs(node.Value, env, &eh, printBuff, isGui)
-		return evalShowStmt(args, printBuff, isGui)
+		args := evalExprs(node.Value, env, &eh)
+		if len(args) == 1 && object.IsErr(args[0]) {
+			return args[0]
+		}
+
+		return evalShowStmt(args, env)
 	case *ast.BlockStmt:
-		return evalBlockStmt(node, env, &eh, printBuff, isGui, false)
+		return evalBlockStmt(node, env, &eh, false)
 	case *ast.LetStmt:
-		return evalLetStmt(node, env, &eh, printBuff, isGui)
+		return evalLetStmt(node, env, &eh)
 	case *ast.Identifier:
 		return evalId(node, env, &eh)
 	//case *ast.IncludeId:
@@ -91,7 +96,7 @@ func Eval(
 		pms := node.Params
 		body := node.Body
 		e, _ := env.GetDefaultEnv()
-		return &object.Function{Name: node.TokenLit(), Params: pms, Body: body, Env: &e, Token: node.Token}
+		return &object.Function{Name: node.TokenLit(), Params: pms, Body: body, Env: &e, Token: node.Token, Doc: node.Doc}
 	case *ast.CallExpr:
 		//
 		// Function Call
@@ -99,43 +104,69 @@ func Eval(
 		//
 		isMod := len(strings.Split(node.Func.String(), ".")) == 2
 
-		fnc := Eval(node.Func, env, eh, printBuff, isGui)
+		fnc := Eval(node.Func, env, eh)
 		//fmt.Println(isMod)
 		if object.IsErr(fnc) {
 			return fnc
 		}
-		args := evalExprs(node.Args, env, &eh, printBuff, isGui)
+		args := evalExprs(node.Args, env, &eh)
 		if len(args) == 1 && object.IsErr(args[0]) {
 			return args[0]
 		}
 
-		return applyFunc(fnc, node.Token, args, isMod, env, &eh, printBuff, isGui)
+		return applyFunc(fnc, node.Token, args, isMod, env, &eh)
 
 	case *ast.StringLit:
 		return &object.String{Value: node.Value, Token: node.Token}
+	case *ast.ConcatExpr:
+		parts := evalExprs(node.Parts, env, &eh)
+		if len(parts) == 1 && object.IsErr(parts[0]) {
+			return parts[0]
+		}
+
+		return allocated(env.GetRuntime().Concat(parts, node.Token), env)
 	case *ast.ArrLit:
-		elms := evalExprs(node.Elms, env, &eh, printBuff, isGui)
+		elms := evalExprs(node.Elms, env, &eh)
 		if len(elms) == 1 && object.IsErr(elms[0]) {
 			return elms[0]
 		}
 
-		return &object.Array{Elms: elms, Token: node.Token}
+		return allocated(&object.Array{Elms: elms, Token: node.Token}, env)
 
 	case *ast.IndexExpr:
 		// node.Left ==> The Array --> ARRAY[index]
-		left := Eval(node.Left, env, eh, printBuff, isGui)
+		left := Eval(node.Left, env, eh)
 		if object.IsErr(left) {
-			return nil
+			return left
 		}
 		// node.Index ==> The Array Index --> array[INDEX]
-		index := Eval(node.Index, env, eh, printBuff, isGui)
+		index := Eval(node.Index, env, eh)
 		if object.IsErr(index) {
 			return index
 		}
 
 		return evalIndexExpr(left, index, &eh)
+	case *ast.SliceExpr:
+		left := Eval(node.Left, env, eh)
+		if object.IsErr(left) {
+			return left
+		}
+
+		bounds := []object.Obj{nil, nil}
+		for i, b := range []ast.Expr{node.Start, node.End} {
+			if b == nil {
+				continue
+			}
+
+			bounds[i] = Eval(b, env, eh)
+			if object.IsErr(bounds[i]) {
+				return bounds[i]
+			}
+		}
+
+		return allocated(evalSliceExpr(left, bounds[0], bounds[1], &eh), env)
 	case *ast.HashLit:
-		return evalHashLit(node, env, &eh, printBuff, isGui)
+		return allocated(evalHashLit(node, env, &eh), env)
 	case *ast.IncludeExpr:
 		return &object.IncludeObj{Filename: node.Filename.String()}
 	case *ast.Break:
@@ -145,6 +176,16 @@ func Eval(
 	return nil
 }
 
+// allocated counts a newly created object for the allocation limit of
+// the runtime
+func allocated(obj object.Obj, env *object.EnvMap) object.Obj {
+	if err := env.GetRuntime().Alloc(obj); err != nil {
+		return object.WrapErr(err)
+	}
+
+	return obj
+}
+
 func evalId(
 	node *ast.Identifier,
 	env *object.EnvMap,
@@ -163,7 +204,7 @@ func evalId(
 		}
 	}
 
-	if builtin, ok := builtins[node.Value]; ok {
+	if builtin, ok := stdlib.GetBuiltin(node.Value); ok {
 		return builtin
 	}
 
@@ -175,13 +216,16 @@ func evalProg(
 	prog *ast.Program,
 	env *object.EnvMap,
 	eh *object.ErrorHelper,
-	printBuff *bytes.Buffer,
-	isGui bool,
 ) object.Obj {
 	var res object.Obj
 
+	// builtins call the functions they are given through the runtime
+	env.GetRuntime().Caller = func(eh *object.ErrorHelper, caller token.Token, fn object.Obj, args []object.Obj) object.Obj {
+		return applyFunc(fn, caller, args, false, env, eh)
+	}
+
 	for _, stmt := range prog.Stmts {
-		res = Eval(stmt, env, *eh, printBuff, isGui)
+		res = Eval(stmt, env, *eh)
 
 		switch res := res.(type) {
 		case *object.ReturnValue:
@@ -198,15 +242,13 @@ func evalLetStmt(
 	node *ast.LetStmt,
 	env *object.EnvMap,
 	eh *object.ErrorHelper,
-	printBuff *bytes.Buffer,
-	isGui bool,
 ) object.Obj {
 
 	if node.Name.IsMod {
 		return object.NewBareErr("Dot notation can not be used directly")
 	}
 
-	val := Eval(node.Value, env, *eh, printBuff, isGui)
+	val := Eval(node.Value, env, *eh)
 	//fmt.Println(val)
 
 	if val.Type() == object.FUNC_OBJ {
@@ -217,7 +259,17 @@ func evalLetStmt(
 	if val.Type() == object.INCLUDE_OBJ {
 		//fmt.Println(val.Inspect())
 		iobj := val.(*object.IncludeObj)
-		evaluateInclude(env, eh, printBuff, isGui, node.Name.Value, iobj.Filename)
+		if err := evaluateInclude(env, eh, node.Name.Value, iobj.Filename); err != nil {
+			e := object.NewErr(node.Token, eh, false, "%s", err.Error())
+
+			// the host can still find a limit error of the module
+			var merr *object.Error
+			if errors.As(err, &merr) {
+				e.Err = merr.Err
+			}
+
+			return e
+		}
 
 		val = &object.String{Value: iobj.Filename}
 	}
@@ -233,9 +285,7 @@ func evalLetStmt(
 
 func evaluateInclude(env *object.EnvMap,
 	eh *object.ErrorHelper,
-	printBuff *bytes.Buffer,
-	isGui bool,
-	key string, filename string) {
+	key string, filename string) error {
 	//e := object.NewEnv()
 	/*	fname := filename
 		if filepath.IsAbs(filename) {
@@ -274,21 +324,63 @@ func evaluateInclude(env *object.EnvMap,
 
 		fdata, _ := os.ReadFile(fname)
 	*/
-	fdata, ok := stdlib.GetStdLibFileSrc(filename)
+	ex := object.NewEnvMap()
+	ex.Runtime = env.Runtime
+
+	if mod, ok := stdlib.FindModule(env.GetRuntime(), filename); ok {
+		for _, name := range mod.Members() {
+			member, _ := mod.Member(name)
+			ex.SetToDefault(name, member)
+		}
+
+		if len(mod.Source) > 0 {
+			if err := evalIncludeSrc(filename, mod.Source, ex, eh); err != nil {
+				return err
+			}
+		}
+	} else {
+		fdata, err := stdlib.LoadModuleSrc(env.GetRuntime(), filename)
 
-	if !ok {
-		fmt.Println("Include file does not exist")
+		if err != nil {
+			return err
+		}
+
+		if err := evalIncludeSrc(filename, fdata, ex, eh); err != nil {
+			return err
+		}
 	}
 
-	l := lexer.NewLexer(fdata)
-	p := parser.NewParser(&l)
-	ex := object.NewEnvMap()
-	prog := p.ParseProg()
-	Eval(prog, ex, *eh, printBuff, isGui)
 	x, _ := ex.GetDefaultEnv()
 
 	env.MergeEnv(key, &x)
 	//fmt.Println(key, filename)
+	return nil
+}
+
+// evalIncludeSrc evaluates the source of the module called name; it
+// returns the errors in the source, if it can not be parsed, or the error
+// its code stopped for
+func evalIncludeSrc(
+	name string,
+	src string,
+	env *object.EnvMap,
+	eh *object.ErrorHelper,
+) error {
+	// modules are read with the keyword pack of the program
+	prog, err := stdlib.ParseModule(env.GetRuntime(), name, src)
+	if err != nil {
+		return err
+	}
+
+	// the errors of the module show the lines of its own source
+	meh := *eh
+	meh.Source = src
+	if res := Eval(prog, env, meh); object.IsErr(res) {
+		e := res.(*object.Error)
+		return &object.Error{Msg: meh.Sprintf(meh.Msg("MODULE_RUN_FAILED"), name, e.Msg), Err: e.Err}
+	}
+
+	return nil
 }
 
 func evalMinusPrefOp(right object.Obj, eh *object.ErrorHelper) object.Obj {
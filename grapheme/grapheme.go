@@ -0,0 +1,178 @@
+// Package grapheme splits text into user-perceived characters (grapheme
+// clusters) and measures how wide text is on a terminal.
+//
+// It follows the extended grapheme cluster rules of Unicode and also
+// keeps Indic conjuncts such as ক্ষ together (rule GB9c of Unicode 15.1),
+// which older implementations split after the hasanta.
+package grapheme
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// linkers are the viramas which join two consonants into a conjunct
+var linkers = map[rune]bool{
+	'्': true, // devanagari
+	'্': true, // bengali
+	'્': true, // gujarati
+	'୍': true, // oriya
+	'్': true, // telugu
+	'്': true, // malayalam
+}
+
+// consonants are the letters with the Indic_Conjunct_Break property
+// Consonant, for the scripts of linkers
+var consonants = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x0915, Hi: 0x0939, Stride: 1},
+		{Lo: 0x0958, Hi: 0x095F, Stride: 1},
+		{Lo: 0x0978, Hi: 0x097F, Stride: 1},
+		{Lo: 0x0995, Hi: 0x09A8, Stride: 1},
+		{Lo: 0x09AA, Hi: 0x09B0, Stride: 1},
+		{Lo: 0x09B2, Hi: 0x09B6, Stride: 4},
+		{Lo: 0x09B7, Hi: 0x09B9, Stride: 1},
+		{Lo: 0x09DC, Hi: 0x09DD, Stride: 1},
+		{Lo: 0x09DF, Hi: 0x09DF, Stride: 1},
+		{Lo: 0x09F0, Hi: 0x09F1, Stride: 1},
+		{Lo: 0x0A95, Hi: 0x0AA8, Stride: 1},
+		{Lo: 0x0AAA, Hi: 0x0AB0, Stride: 1},
+		{Lo: 0x0AB2, Hi: 0x0AB3, Stride: 1},
+		{Lo: 0x0AB5, Hi: 0x0AB9, Stride: 1},
+		{Lo: 0x0AF9, Hi: 0x0AF9, Stride: 1},
+		{Lo: 0x0B15, Hi: 0x0B28, Stride: 1},
+		{Lo: 0x0B2A, Hi: 0x0B30, Stride: 1},
+		{Lo: 0x0B32, Hi: 0x0B33, Stride: 1},
+		{Lo: 0x0B35, Hi: 0x0B39, Stride: 1},
+		{Lo: 0x0B5C, Hi: 0x0B5D, Stride: 1},
+		{Lo: 0x0B5F, Hi: 0x0B5F, Stride: 1},
+		{Lo: 0x0B71, Hi: 0x0B71, Stride: 1},
+		{Lo: 0x0C15, Hi: 0x0C28, Stride: 1},
+		{Lo: 0x0C2A, Hi: 0x0C39, Stride: 1},
+		{Lo: 0x0C58, Hi: 0x0C5A, Stride: 1},
+		{Lo: 0x0D15, Hi: 0x0D3A, Stride: 1},
+	},
+}
+
+// IsConsonant reports if r is a consonant which can be part of a conjunct
+func IsConsonant(r rune) bool {
+	return unicode.Is(consonants, r)
+}
+
+// IsLinker reports if r is a virama (such as the bengali hasanta) which
+// joins consonants
+func IsLinker(r rune) bool {
+	return linkers[r]
+}
+
+// joinsNext reports if a cluster ends with a consonant, a linker and
+// possibly marks after them, so that a consonant after it belongs to it
+func joinsNext(cluster string) bool {
+	rs := []rune(cluster)
+
+	i := len(rs) - 1
+	for i >= 0 && !IsLinker(rs[i]) && isConjunctExtend(rs[i]) {
+		i--
+	}
+
+	if i < 1 || !IsLinker(rs[i]) {
+		return false
+	}
+
+	return IsConsonant(rs[0])
+}
+
+// isConjunctExtend reports if r can come between a linker and the next
+// consonant of a conjunct (such as a nukta or a zero width joiner)
+func isConjunctExtend(r rune) bool {
+	return r == '‍' || unicode.Is(unicode.Mn, r)
+}
+
+// Clusters splits s into grapheme clusters
+func Clusters(s string) []string {
+	clusters := []string{}
+
+	state := -1
+	for len(s) > 0 {
+		var c string
+		c, s, _, state = uniseg.StepString(s, state)
+
+		if n := len(clusters); n > 0 && joinsNext(clusters[n-1]) && startsWithConsonant(c) {
+			clusters[n-1] += c
+			continue
+		}
+
+		clusters = append(clusters, c)
+	}
+
+	return clusters
+}
+
+func startsWithConsonant(s string) bool {
+	for _, r := range s {
+		return IsConsonant(r)
+	}
+
+	return false
+}
+
+// Count returns the number of grapheme clusters in s
+func Count(s string) int {
+	return len(Clusters(s))
+}
+
+// Reverse reverses s cluster by cluster, so that vowel signs and
+// conjuncts stay attached to their letters
+func Reverse(s string) string {
+	cs := Clusters(s)
+	out := make([]byte, 0, len(s))
+
+	for i := len(cs) - 1; i >= 0; i-- {
+		out = append(out, cs[i]...)
+	}
+
+	return string(out)
+}
+
+// Width returns how many columns s takes on a terminal
+func Width(s string) int {
+	w := 0
+	for _, c := range Clusters(s) {
+		w += ClusterWidth(c)
+	}
+
+	return w
+}
+
+// ClusterWidth returns how many columns a single grapheme cluster takes
+// on a terminal. Like most terminals, it counts spacing vowel signs
+// (such as া) as a column of their own, while emoji sequences take the
+// width of a single emoji
+func ClusterWidth(c string) int {
+	if c == "\t" {
+		return TabWidth
+	}
+
+	// wide letters and emoji
+	if r, _ := utf8.DecodeRuneInString(c); uniseg.StringWidth(string(r)) > 1 {
+		return uniseg.StringWidth(c)
+	}
+
+	w := 0
+	for _, r := range c {
+		switch {
+		case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		case unicode.Is(unicode.Mc, r):
+			w++
+		default:
+			w += uniseg.StringWidth(string(r))
+		}
+	}
+
+	return w
+}
+
+// TabWidth is the number of columns a tab is shown with
+const TabWidth = 4
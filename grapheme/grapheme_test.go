@@ -0,0 +1,54 @@
+package grapheme
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClusters(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"বাংলা", []string{"বাং", "লা"}},
+		{"ক্ষমা", []string{"ক্ষ", "মা"}},
+		{"স্ত্রী", []string{"স্ত্রী"}},
+		{"কার্য", []string{"কা", "র্য"}},
+		{"হিন্দি", []string{"হি", "ন্দি"}},
+		{"ক্‍ষ", []string{"ক্‍ষ"}},
+		{"ab", []string{"a", "b"}},
+		{"", []string{}},
+	}
+
+	for i, tt := range tests {
+		got := Clusters(tt.input)
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("tests[%d] -> expected %q, got %q", i, tt.expected, got)
+		}
+	}
+}
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"abc", 3},
+		{"ক্ষমা", 4},
+		{"শুভ্র", 3},
+		{"a\tb", 2 + TabWidth},
+		{"日本", 4},
+	}
+
+	for i, tt := range tests {
+		if got := Width(tt.input); got != tt.expected {
+			t.Errorf("tests[%d] -> expected %d, got %d", i, tt.expected, got)
+		}
+	}
+}
+
+func TestReverse(t *testing.T) {
+	if got := Reverse("ক্ষমা"); got != "মাক্ষ" {
+		t.Errorf("expected %q, got %q", "মাক্ষ", got)
+	}
+}
@@ -5,14 +5,11 @@ package ide
 
 import (
 	"bytes"
-	"io"
+	"context"
 	"os"
-	"strings"
 
-	"go.cs.palashbauri.in/pankti/evaluator"
-	"go.cs.palashbauri.in/pankti/lexer"
-	"go.cs.palashbauri.in/pankti/object"
-	"go.cs.palashbauri.in/pankti/parser"
+	"github.com/gen2brain/iup-go/iup"
+	"go.cs.palashbauri.in/pankti/pankti"
 )
 
 func OpenFile(filename string) (string, error) {
@@ -44,38 +41,20 @@ func SaveFile(filename string, content string, overwrite bool) error {
 
 func RunFile(src string) string {
 
-	l := lexer.NewLexer(src)
-	p := parser.NewParser(&l)
-
-	prog := p.ParseProg()
-
-	if len(p.GetErrors()) >= 1 {
-		tempErrs := []string{}
-
-		for _, item := range p.GetErrors() {
-			tempErrs = append(tempErrs, item.String())
-		}
-
-		return strings.Join(tempErrs, " \n")
-	}
-
-	env := object.NewEnvMap()
-	eh := object.ErrorHelper{Source: src}
-	printBuff := bytes.Buffer{}
-	evd := evaluator.Eval(prog, env, eh, &printBuff, true)
-	//rd, _ := ioutil.ReadAll(&printBuff)
-	rd, err := io.ReadAll(&printBuff)
-
-	printValue := ""
-
-	if err == nil {
-		printValue = string(rd[:])
-	}
-
-	if evd != nil {
-		return printValue + evd.Inspect()
-	} else {
-		return printValue
+	out := bytes.Buffer{}
+	it := pankti.New(
+		pankti.WithStdout(&out),
+		pankti.WithStderr(&out),
+		// the ide has no console, so input is asked for with a dialog
+		pankti.WithInput(func(prompt string) (string, error) {
+			return iup.GetText(prompt, ""), nil
+		}),
+	)
+	evd, err := it.Run(context.Background(), src)
+
+	if err == nil && evd != nil {
+		out.WriteString(evd.Inspect())
 	}
 
+	return out.String()
 }
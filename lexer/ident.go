@@ -0,0 +1,85 @@
+package lexer
+
+import "unicode"
+
+// Identifiers follow the Unicode identifier rules (UAX #31): they start
+// with a letter (XID_Start) or `_`, and go on with letters, marks, digits
+// and connectors (XID_Continue), which covers Bengali and Assamese
+// letters, vowel signs and digits. The zero width joiner and non joiner
+// are allowed after the first character, as they are needed to spell
+// words such as র‍্যাব
+
+// the characters which are ID_Start or ID_Continue but are left out of
+// XID_Start and XID_Continue, as they change under NFKC
+var notXIDStart = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x037a, Hi: 0x037a, Stride: 1},
+		{Lo: 0x0e33, Hi: 0x0e33, Stride: 1},
+		{Lo: 0x0eb3, Hi: 0x0eb3, Stride: 1},
+		{Lo: 0x309b, Hi: 0x309c, Stride: 1},
+		{Lo: 0xfc5e, Hi: 0xfc63, Stride: 1},
+		{Lo: 0xfdfa, Hi: 0xfdfb, Stride: 1},
+		{Lo: 0xfe70, Hi: 0xfe7e, Stride: 2},
+		{Lo: 0xff9e, Hi: 0xff9f, Stride: 1},
+	},
+}
+
+var notXIDContinue = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x037a, Hi: 0x037a, Stride: 1},
+		{Lo: 0x309b, Hi: 0x309c, Stride: 1},
+		{Lo: 0xfc5e, Hi: 0xfc63, Stride: 1},
+		{Lo: 0xfdfa, Hi: 0xfdfb, Stride: 1},
+		{Lo: 0xfe70, Hi: 0xfe7e, Stride: 2},
+	},
+}
+
+// isLetter reports whether ch can start an identifier
+func isLetter(ch rune) bool {
+	if ch == '_' {
+		return true
+	}
+
+	if ch < 0x80 {
+		return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z'
+	}
+
+	return isIDStart(ch) && !unicode.Is(notXIDStart, ch)
+}
+
+// isIdentChar reports whether ch can be a part of an identifier after
+// its first character
+func isIdentChar(ch rune) bool {
+	if ch < 0x80 {
+		return isLetter(ch) || '0' <= ch && ch <= '9'
+	}
+
+	if unicode.Is(unicode.Join_Control, ch) {
+		return true
+	}
+
+	return isIDContinue(ch) && !unicode.Is(notXIDContinue, ch)
+}
+
+func isIDStart(ch rune) bool {
+	if isPattern(ch) {
+		return false
+	}
+
+	return unicode.IsOneOf([]*unicode.RangeTable{unicode.L, unicode.Nl, unicode.Other_ID_Start}, ch)
+}
+
+func isIDContinue(ch rune) bool {
+	if isPattern(ch) {
+		return false
+	}
+
+	return isIDStart(ch) ||
+		unicode.IsOneOf([]*unicode.RangeTable{unicode.Mn, unicode.Mc, unicode.Nd, unicode.Pc, unicode.Other_ID_Continue}, ch)
+}
+
+// isPattern reports whether ch is kept for the syntax of languages, so it
+// can never be a part of an identifier
+func isPattern(ch rune) bool {
+	return unicode.In(ch, unicode.Pattern_Syntax, unicode.Pattern_White_Space)
+}
@@ -1,8 +1,14 @@
 package lexer
 
 import (
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
+	"go.cs.palashbauri.in/pankti/errs"
+	"go.cs.palashbauri.in/pankti/number"
+	"go.cs.palashbauri.in/pankti/pack"
 	"go.cs.palashbauri.in/pankti/token"
 )
 
@@ -13,6 +19,57 @@ type Lexer struct {
 	ch      rune
 	line    int
 	column  int
+	// byte offset of every rune of the input, and of the end of it
+	offsets []int
+	errors  []Error
+	// the strings whose expressions are being read, innermost last
+	interps []interp
+	// the keyword pack chosen by the first line of the input, or else
+	// the one given to NewLexerWithPack; nil for the Bengali pack
+	pack *pack.Pack
+}
+
+// interp is a string with an expression in it which is being read
+type interp struct {
+	// where the string starts
+	open token.Pos
+	// number of unclosed `{` inside of the expression
+	depth int
+}
+
+// Error is a malformed part of the input, found while reading tokens
+type Error struct {
+	// key of the message in errs.Errs
+	Key string
+	// the malformed part
+	Token token.Token
+	// values for the message
+	Args []interface{}
+}
+
+// Errors returns the errors found so far
+func (l *Lexer) Errors() []Error {
+	return l.errors
+}
+
+// errorAt records an error about lit, which starts at start on a single
+// line
+func (l *Lexer) errorAt(key string, start token.Pos, lit string, args ...interface{}) {
+	end := start
+	end.Offset += len(lit)
+	end.Column += utf8.RuneCountInString(lit)
+
+	l.errors = append(l.errors, Error{
+		Key: key,
+		Token: token.Token{
+			Type:    token.ILLEGAL,
+			Literal: lit,
+			LineNo:  start.Line,
+			Column:  start.Column,
+			Span:    token.Span{Start: start, End: end},
+		},
+		Args: args,
+	})
 }
 
 func (l *Lexer) AtEOF() bool {
@@ -30,7 +87,29 @@ func getLen(inp string) int {
 */
 
 func NewLexer(input string) Lexer {
-	lexer := Lexer{input: []rune(input), line: 1, column: 0}
+	return NewLexerWithPack(input, nil)
+}
+
+// NewLexerWithPack is like NewLexer but reads the keywords of p, unless
+// the first line of the input chooses another pack
+func NewLexerWithPack(input string, p *pack.Pack) Lexer {
+	lexer := Lexer{input: []rune(input), line: 1, column: 0, pack: p}
+
+	lexer.offsets = make([]int, 0, len(lexer.input)+1)
+	for offset := range input {
+		lexer.offsets = append(lexer.offsets, offset)
+	}
+	lexer.offsets = append(lexer.offsets, len(input))
+
+	if name, offset, ok := pack.Directive(input); ok {
+		if fp, found := pack.Get(name); found {
+			lexer.pack = fp
+		} else {
+			start := token.Pos{Offset: offset, Line: 1, Column: utf8.RuneCountInString(input[:offset]) + 1}
+			lexer.errorAt(errs.UNKNOWN_PACK, start, name, name)
+		}
+	}
+
 	lexer.readChar()
 	return lexer
 }
@@ -38,6 +117,11 @@ func NewLexer(input string) Lexer {
 func (l *Lexer) readChar() {
 	//Advances lexer
 
+	if l.ch == '\n' && l.pos < len(l.input) {
+		l.line++
+		l.column = 0
+	}
+
 	if l.readPos >= len(l.input) {
 		l.ch = 0
 	} else {
@@ -50,6 +134,20 @@ func (l *Lexer) readChar() {
 	l.column++
 }
 
+// Pack returns the keyword pack of the input
+func (l *Lexer) Pack() *pack.Pack {
+	if l.pack == nil {
+		return pack.Default
+	}
+
+	return l.pack
+}
+
+// Source returns the whole input of the lexer
+func (l *Lexer) Source() string {
+	return string(l.input)
+}
+
 func (l *Lexer) GetLine(ln int) string {
 	a := strings.Split(string(l.input), "\n")
 
@@ -71,11 +169,37 @@ func (l *Lexer) GetLine(ln int) string {
 	return ""
 }
 
+// here returns the position of the current character
+func (l *Lexer) here() token.Pos {
+	offset := l.offsets[len(l.offsets)-1]
+	if l.pos < len(l.offsets) {
+		offset = l.offsets[l.pos]
+	}
+
+	return token.Pos{Offset: offset, Line: l.line, Column: l.column}
+}
+
 func (l *Lexer) NextToken() token.Token {
 	// Get next token
+	l.eatWhitespace()
+	for l.ch == '/' && l.peekChar() == '*' {
+		// block comments are skipped like whitespace, so they can be
+		// put anywhere
+		l.eatBlockComment()
+		l.eatWhitespace()
+	}
+
+	start := l.here()
+	tk := l.readToken()
 
+	tk.LineNo = start.Line
+	tk.Column = start.Column
+	tk.Span = token.Span{Start: start, End: l.here()}
+	return tk
+}
+
+func (l *Lexer) readToken() token.Token {
 	var tk token.Token
-	l.eatWhitespace()
 	switch l.ch {
 
 	case '+':
@@ -138,8 +262,22 @@ func (l *Lexer) NextToken() token.Token {
 	case ')':
 		tk = NewToken(token.RPAREN, l.ch, l.line, l.column)
 	case '{':
+		if n := len(l.interps); n > 0 {
+			l.interps[n-1].depth++
+		}
 		tk = NewToken(token.LBRACE, l.ch, l.line, l.column)
 	case '}':
+		n := len(l.interps)
+		if n > 0 && l.interps[n-1].depth == 0 {
+			// the end of an expression in a string
+			open := l.interps[n-1].open
+			l.interps = l.interps[:n-1]
+			tk.Literal, tk.Type = l.readString(open, token.STRING_TAIL, token.STRING_MID)
+			break
+		}
+		if n > 0 {
+			l.interps[n-1].depth--
+		}
 		tk = NewToken(token.RBRACE, l.ch, l.line, l.column)
 	case '%':
 		tk = NewToken(token.MOD, l.ch, l.line, l.column)
@@ -158,10 +296,10 @@ func (l *Lexer) NextToken() token.Token {
 			tk = NewToken(token.EXC, l.ch, l.line, l.column)
 		}
 	case '"':
+		tk.Literal, tk.Type = l.readString(l.here(), token.STRING, token.STRING_HEAD)
+	case '`':
 		tk.Type = token.STRING
-		tk.LineNo = l.line
-		tk.Column = l.column
-		tk.Literal = l.readString()
+		tk.Literal = l.readRawString()
 	case '[':
 		tk = NewToken(token.LS_BRACKET, l.ch, l.line, l.column)
 	case ']':
@@ -176,6 +314,13 @@ func (l *Lexer) NextToken() token.Token {
 		col := l.column
 		lin := l.line
 
+		var tokType token.TokenType = token.COMMENT
+		if l.peekChar() == '#' {
+			tokType = token.DOC_COMMENT
+			l.readChar()
+			pos++
+		}
+
 		for {
 
 			l.readChar()
@@ -186,9 +331,15 @@ func (l *Lexer) NextToken() token.Token {
 
 		}
 
-		tk = token.Token{
-			Type:    token.COMMENT,
-			Literal: string(l.input[pos:l.pos]),
+		lit := string(l.input[pos:l.pos])
+		if tokType == token.DOC_COMMENT {
+			lit = strings.TrimPrefix(strings.TrimRight(lit, " \t"), " ")
+		}
+
+		// the line ending is not part of the comment
+		return token.Token{
+			Type:    tokType,
+			Literal: lit,
 			LineNo:  lin,
 			Column:  col,
 		}
@@ -201,6 +352,11 @@ func (l *Lexer) NextToken() token.Token {
 		//l.eatWhitespace()
 
 	case 0:
+		for _, s := range l.interps {
+			l.errorAt(errs.UNTERMINATED_STRING, s.open, `"`, s.open.Line, s.open.Column, `"`)
+		}
+		l.interps = nil
+
 		tk.Column = l.column
 		tk.LineNo = l.line
 		tk.Literal = ""
@@ -212,13 +368,13 @@ func (l *Lexer) NextToken() token.Token {
 			tk.Column = l.column
 			lit, _ := l.readIdent()
 			tk.Literal = lit
-			tk.Type = token.LookupIdent(tk.Literal)
+			tk.Type = l.lookupIdent(tk.Literal)
 
 			return tk
 		} else if isDigit(l.ch) {
 			tk.LineNo = l.line
 			tk.Column = l.column
-			lit, _ := l.readNum()
+			lit := l.readNum()
 
 			//fmt.Println(lit)
 			tk.Literal = lit
@@ -251,25 +407,179 @@ func (l *Lexer) eatSingleLineComment(){
 }
 */
 
-func (l *Lexer) readString() string {
+// readString reads a string in double quotes, which starts at open, and
+// replaces the escape sequences in it. It returns the type end if the
+// string ends with a quote, and head if an expression starts in it with
+// a `{`
+func (l *Lexer) readString(open token.Pos, end, head token.TokenType) (string, token.TokenType) {
+	out := strings.Builder{}
+
+	for {
+		l.readChar()
+
+		switch {
+		case l.AtEOF():
+			l.errorAt(errs.UNTERMINATED_STRING, open, `"`, open.Line, open.Column, `"`)
+			return out.String(), end
+		case l.ch == '"':
+			return out.String(), end
+		case l.ch == '{' && l.strayBrace():
+			// reported once here and kept as it is, so that the rest of
+			// the source is read as if it was escaped
+			l.errorAt(errs.STRAY_BRACE, l.here(), "{")
+			out.WriteRune('{')
+		case l.ch == '{':
+			l.interps = append(l.interps, interp{open: open})
+			return out.String(), head
+		case l.ch == '\\':
+			l.readEscape(&out)
+		default:
+			out.WriteRune(l.ch)
+		}
+	}
+}
+
+// strayBrace reports if the `{` at the current position can not start an
+// expression in a string: nothing but spaces follows it before its `}`,
+// or the line has no `}` to close it
+func (l *Lexer) strayBrace() bool {
+	depth := 0
+	empty := true
+
+	for i := l.readPos; i < len(l.input) && l.input[i] != '\n'; i++ {
+		switch ch := l.input[i]; {
+		case ch == '{':
+			depth++
+		case ch == '}' && depth == 0:
+			return empty
+		case ch == '}':
+			depth--
+		}
+
+		if !unicode.IsSpace(l.input[i]) {
+			empty = false
+		}
+	}
+
+	return true
+}
+
+// readEscape reads the escape sequence starting at the current backslash
+// into out; invalid sequences are kept as they are
+func (l *Lexer) readEscape(out *strings.Builder) {
+	start := l.here()
+	if l.peekChar() == 0 {
+		// the string is not closed, which readString reports
+		return
+	}
+
+	l.readChar()
+	switch l.ch {
+	case 'n':
+		out.WriteRune('\n')
+	case 't':
+		out.WriteRune('\t')
+	case '"', '\\', '{', '}':
+		out.WriteRune(l.ch)
+	case 'u':
+		lit, r, ok := l.readUnicodeEscape()
+		if ok {
+			out.WriteRune(r)
+			return
+		}
+
+		l.errorAt(errs.INVALID_ESCAPE, start, `\`+lit, `\`+lit)
+		out.WriteString(`\` + lit)
+	default:
+		lit := `\` + string(l.ch)
+		l.errorAt(errs.INVALID_ESCAPE, start, lit, lit)
+		out.WriteString(lit)
+	}
+}
+
+// readUnicodeEscape reads the `{...}` after `\u` and returns the text of
+// the escape sequence and the character it names. It stops before
+// anything which can not be part of the sequence, so that a closing quote
+// is never consumed
+func (l *Lexer) readUnicodeEscape() (string, rune, bool) {
+	lit := "u"
+	if l.peekChar() != '{' {
+		return lit, 0, false
+	}
+	l.readChar()
+
+	digits := []rune{}
+	for isHexDigit(l.peekChar()) {
+		l.readChar()
+		digits = append(digits, l.ch)
+	}
+	lit += "{" + string(digits)
+
+	if l.peekChar() != '}' {
+		return lit, 0, false
+	}
+	l.readChar()
+	lit += "}"
+
+	if len(digits) == 0 || len(digits) > 6 {
+		return lit, 0, false
+	}
+
+	n, err := strconv.ParseUint(number.ToASCIIDigits(string(digits)), 16, 32)
+	if err != nil || !utf8.ValidRune(rune(n)) {
+		return lit, 0, false
+	}
+
+	return lit, rune(n), true
+}
+
+// readRawString reads a string in backticks, which can span lines and
+// has no escape sequences
+func (l *Lexer) readRawString() string {
+	open := l.here()
 	pos := l.pos + 1
 
 	for {
 		l.readChar()
-		if l.ch == '"' || l.ch == 0 {
-			break
+
+		if l.AtEOF() {
+			l.errorAt(errs.UNTERMINATED_STRING, open, "`", open.Line, open.Column, "`")
+			return string(l.input[pos:])
+		}
+
+		if l.ch == '`' {
+			return string(l.input[pos:l.pos])
 		}
 	}
-	//fmt.Println(l.input[pos:l.pos])
-	return string(l.input[pos:l.pos])
+}
+
+// eatBlockComment skips a comment between `/*` and `*/`, which can
+// contain other block comments
+func (l *Lexer) eatBlockComment() {
+	open := l.here()
+	depth := 0
+
+	for !l.AtEOF() {
+		switch {
+		case l.ch == '/' && l.peekChar() == '*':
+			depth++
+			l.readChar()
+		case l.ch == '*' && l.peekChar() == '/':
+			depth--
+			l.readChar()
+		}
+
+		l.readChar()
+		if depth == 0 {
+			return
+		}
+	}
+
+	l.errorAt(errs.UNTERMINATED_COMMENT, open, "/*", open.Line, open.Column)
 }
 
 func (l *Lexer) eatWhitespace() {
 	for l.ch == rune(' ') || l.ch == '\t' || l.ch == '\n' || l.ch == '\r' {
-		if l.ch == '\n' {
-			l.line++
-			l.column = 0
-		}
 		l.readChar()
 		//log.Println(l.ch)
 
@@ -292,82 +602,129 @@ func NewToken(
 
 }
 
+func (l *Lexer) lookupIdent(ident string) token.TokenType {
+	if l.pack != nil {
+		return l.pack.LookupIdent(ident)
+	}
+
+	return token.LookupIdent(ident)
+}
+
 func (l *Lexer) readIdent() (string, bool) {
 
 	pos := l.pos
 	isModId := false
-	for isLetter(l.ch) {
+	for isIdentChar(l.ch) {
 		l.readChar()
 	}
 
 	if l.ch == '.' {
 		isModId = true
 		l.readChar()
-		for isLetter(l.ch) {
+		for isIdentChar(l.ch) {
 			l.readChar()
 		}
 	}
-	return string(l.input[pos:l.pos]), isModId
+	return token.Normalize(string(l.input[pos:l.pos])), isModId
 
 }
 
-func (l *Lexer) readNum() (string, bool) {
+// readNum reads a number literal and returns it with ascii digits and
+// without the `_` separators, such as 100000 for ১_০০_০০০, 1.5e10, 0x1F
+// or 0b1010. A malformed literal is reported and read as 0
+func (l *Lexer) readNum() string {
+	start := l.here()
 	pos := l.pos
-	isFloat := false
+	out := []rune{}
+	ok := true
 
-	for isDigit(l.ch) {
-		l.readChar()
-	}
+	if p := l.peekChar(); (l.ch == '0' || l.ch == '০') && strings.ContainsRune("xXbB", p) {
+		valid := isHexDigit
+		if p == 'b' || p == 'B' {
+			valid = isBinaryDigit
+		}
 
-	if l.ch == '.' {
-		isFloat = true
+		out = append(out, '0', unicode.ToLower(p))
+		l.readChar()
 		l.readChar()
-		for isDigit(l.ch) {
+
+		digits, good := l.readDigits(valid)
+		out, ok = append(out, digits...), good
+	} else {
+		digits, good := l.readDigits(isDigit)
+		out, ok = append(out, digits...), good
+
+		if l.ch == '.' {
+			out = append(out, '.')
 			l.readChar()
+
+			if isDigit(l.ch) || l.ch == '_' {
+				digits, good := l.readDigits(isDigit)
+				out, ok = append(out, digits...), ok && good
+			}
 		}
-	}
 
-	//parseBengaliNum(l.input[pos:l.pos])
+		if l.ch == 'e' || l.ch == 'E' {
+			out = append(out, 'e')
+			l.readChar()
 
-	return string(parseBengaliNum(l.input[pos:l.pos])), isFloat
-}
+			if l.ch == '+' || l.ch == '-' {
+				out = append(out, l.ch)
+				l.readChar()
+			}
 
-func parseBengaliNum(inp []rune) []rune {
+			digits, good := l.readDigits(isDigit)
+			out, ok = append(out, digits...), ok && good
+		}
+	}
 
-	var result []rune
+	// such as the 2 of 0b102 or the ক of ১০ক
+	if isIdentChar(l.ch) || isDigit(l.ch) {
+		ok = false
+		for isIdentChar(l.ch) || isDigit(l.ch) {
+			l.readChar()
+		}
+	}
 
-	for _, item := range inp {
+	if !ok {
+		lit := string(l.input[pos:l.pos])
+		l.errorAt(errs.MALFORMED_NUMBER, start, lit, lit)
+		return "0"
+	}
 
-		//fmt.Println(item)
+	return string(out)
+}
 
-		switch item {
-		case '০':
-			result = append(result, '0')
-		case '১':
-			result = append(result, '1')
-		case '২':
-			result = append(result, '2')
-		case '৩':
-			result = append(result, '3')
-		case '৪':
-			result = append(result, '4')
-		case '৫':
-			result = append(result, '5')
-		case '৬':
-			result = append(result, '6')
-		case '৭':
-			result = append(result, '7')
-		case '৮':
-			result = append(result, '8')
-		case '৯':
-			result = append(result, '9')
-		default:
-			result = append(result, item)
+// readDigits reads digits and the `_` separators between them, and
+// returns the digits in ascii. It reports false if a digit is not valid
+// or if a separator is not between two digits
+func (l *Lexer) readDigits(valid func(rune) bool) ([]rune, bool) {
+	digits := []rune{}
+	ok := true
+	afterSep := false
+
+	for isDigit(l.ch) || valid(l.ch) || l.ch == '_' {
+		if l.ch == '_' {
+			if len(digits) == 0 || afterSep {
+				ok = false
+			}
+			afterSep = true
+		} else {
+			if !valid(l.ch) {
+				ok = false
+			}
+			digits = append(digits, l.ch)
+			afterSep = false
 		}
+
+		l.readChar()
 	}
 
-	//fmt.Println(string(result))
-	return result
+	if len(digits) == 0 || afterSep {
+		ok = false
+	}
+
+	return []rune(number.ToASCIIDigits(string(digits))), ok
 }
 
 func (l *Lexer) peekChar() rune {
@@ -375,14 +732,16 @@ func (l *Lexer) peekChar() rune {
 	if l.readPos >= len(l.input) {
 		return 0
 	} else {
-		return []rune(l.input)[l.readPos]
+		return l.input[l.readPos]
 	}
 }
 
-func isLetter(ch rune) bool {
-	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_' ||
-		'ঀ' <= ch && ch <= 'ৡ' ||
-		'ৰ' <= ch && ch <= '৽'
+func isHexDigit(ch rune) bool {
+	return isDigit(ch) || 'a' <= ch && ch <= 'f' || 'A' <= ch && ch <= 'F'
+}
+
+func isBinaryDigit(ch rune) bool {
+	return ch == '0' || ch == '1' || ch == '০' || ch == '১'
 }
 
 func isDigit(ch rune) bool {
@@ -1,6 +1,7 @@
 package lexer
 
 import (
+	"strings"
 	"testing"
 
 	"go.cs.palashbauri.in/pankti/token"
@@ -46,7 +47,7 @@ func TestNextToken(t *testing.T) {
 		{token.IDENT, "lok"},
 		{token.RPAREN, ")"},
 		{token.LBRACE, "{"},
-		{token.IDENT, "dekhau"},
+		{token.SHOW, "dekhau"},
 		{token.LPAREN, "("},
 		{token.IDENT, "lok"},
 		{token.PLUS, "+"},
@@ -87,3 +88,283 @@ func TestNextToken(t *testing.T) {
 	}
 
 }
+
+func TestTokenSpans(t *testing.T) {
+	inp := "dhori নাম = \"পলাশ\" # মন্তব্য\nক == ১০০"
+
+	tests := []struct {
+		expectedType token.TokenType
+		expectedText string
+		expectedLine int
+		expectedCol  int
+	}{
+		{token.LET, "dhori", 1, 1},
+		{token.IDENT, "নাম", 1, 7},
+		{token.EQ, "=", 1, 11},
+		{token.STRING, "\"পলাশ\"", 1, 13},
+		{token.COMMENT, "# মন্তব্য", 1, 20},
+		{token.IDENT, "ক", 2, 1},
+		{token.EQEQ, "==", 2, 3},
+		{token.NUM, "১০০", 2, 6},
+		{token.EOF, "", 2, 9},
+	}
+
+	l := NewLexer(inp)
+
+	for i, tt := range tests {
+		tk := l.NextToken()
+
+		if tk.Type != tt.expectedType {
+			t.Fatalf("tests[%d] -> TokenType wrong -> Expected=%q, Got=%q", i, tt.expectedType, tk.Type)
+		}
+
+		text := inp[tk.Span.Start.Offset:tk.Span.End.Offset]
+		if text != tt.expectedText {
+			t.Errorf("tests[%d] -> span wrong -> Expected=%q, Got=%q", i, tt.expectedText, text)
+		}
+
+		if tk.LineNo != tt.expectedLine || tk.Column != tt.expectedCol {
+			t.Errorf(
+				"tests[%d] -> position wrong -> Expected=%d:%d, Got=%d:%d",
+				i, tt.expectedLine, tt.expectedCol, tk.LineNo, tk.Column,
+			)
+		}
+
+		if tk.Span.Start.Line != tk.LineNo || tk.Span.Start.Column != tk.Column {
+			t.Errorf("tests[%d] -> span does not start at the token", i)
+		}
+	}
+}
+
+func TestStrings(t *testing.T) {
+	tests := []struct {
+		input        string
+		expectedLit  string
+		expectedErrs []string
+	}{
+		{`"ক\tখ\nগ"`, "ক\tখ\nগ", nil},
+		{`"তিনি বললেন \"হ্যাঁ\""`, `তিনি বললেন "হ্যাঁ"`, nil},
+		{`"C:\\ফাইল"`, `C:\ফাইল`, nil},
+		{`"\u{995}\u{০৯৯৬}"`, "কখ", nil},
+		{"`ক\\n\n\"খ\"`", "ক\\n\n\"খ\"", nil},
+		{`"ক\qখ"`, `ক\qখ`, []string{"INVALID_ESCAPE"}},
+		{`"\u{110000}"`, `\u{110000}`, []string{"INVALID_ESCAPE"}},
+		{`"\u{99"`, `\u{99`, []string{"INVALID_ESCAPE"}},
+		{`"কখ`, "কখ", []string{"UNTERMINATED_STRING"}},
+		{"`কখ\n", "কখ\n", []string{"UNTERMINATED_STRING"}},
+		// a `{` which can not start an expression is kept, with one error
+		{`"{"`, "{", []string{"STRAY_BRACE"}},
+		{`"ক { খ"`, "ক { খ", []string{"STRAY_BRACE"}},
+		{`"{ }"`, "{ }", []string{"STRAY_BRACE"}},
+	}
+
+	for i, tt := range tests {
+		l := NewLexer(tt.input)
+		tk := l.NextToken()
+
+		if tk.Type != token.STRING || tk.Literal != tt.expectedLit {
+			t.Errorf("tests[%d] -> Expected=%q, Got=%s %q", i, tt.expectedLit, tk.Type, tk.Literal)
+		}
+
+		if next := l.NextToken(); next.Type != token.EOF {
+			t.Errorf("tests[%d] -> Expected EOF after string, Got=%s %q", i, next.Type, next.Literal)
+		}
+
+		got := []string{}
+		for _, e := range l.Errors() {
+			got = append(got, e.Key)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.expectedErrs, ",") {
+			t.Errorf("tests[%d] -> errors wrong -> Expected=%v, Got=%v", i, tt.expectedErrs, got)
+		}
+	}
+}
+
+func TestUnterminatedStringPosition(t *testing.T) {
+	l := NewLexer("dhori ক = ১\ndhori খ = \"কখ\n")
+	for tk := l.NextToken(); tk.Type != token.EOF; tk = l.NextToken() {
+	}
+
+	if len(l.Errors()) != 1 {
+		t.Fatalf("Expected 1 error, Got=%v", l.Errors())
+	}
+
+	e := l.Errors()[0]
+	if e.Token.LineNo != 2 || e.Token.Column != 11 || e.Token.Literal != `"` {
+		t.Errorf("position wrong -> Got=%d:%d %q", e.Token.LineNo, e.Token.Column, e.Token.Literal)
+	}
+}
+
+func TestInterpolationTokens(t *testing.T) {
+	inp := `"ক {খ} গ {{"ঘ": ঙ}["ঘ"]}"`
+
+	tests := []struct {
+		expectedType token.TokenType
+		expectedLit  string
+	}{
+		{token.STRING_HEAD, "ক "},
+		{token.IDENT, "খ"},
+		{token.STRING_MID, " গ "},
+		{token.LBRACE, "{"},
+		{token.STRING, "ঘ"},
+		{token.COLON, ":"},
+		{token.IDENT, "ঙ"},
+		{token.RBRACE, "}"},
+		{token.LS_BRACKET, "["},
+		{token.STRING, "ঘ"},
+		{token.RS_BRACKET, "]"},
+		{token.STRING_TAIL, ""},
+		{token.EOF, ""},
+	}
+
+	l := NewLexer(inp)
+
+	for i, tt := range tests {
+		tk := l.NextToken()
+
+		if tk.Type != tt.expectedType || tk.Literal != tt.expectedLit {
+			t.Fatalf("tests[%d] -> Expected=%s %q, Got=%s %q", i, tt.expectedType, tt.expectedLit, tk.Type, tk.Literal)
+		}
+	}
+
+	if len(l.Errors()) != 0 {
+		t.Errorf("unexpected errors %v", l.Errors())
+	}
+}
+
+func TestComments(t *testing.T) {
+	inp := `/* বাইরে /* ভিতরে */ এখনও মন্তব্য */ ক /* */ + ১
+## যোগফল
+##   দুটি সংখ্যার
+# সাধারণ`
+
+	tests := []struct {
+		expectedType token.TokenType
+		expectedLit  string
+	}{
+		{token.IDENT, "ক"},
+		{token.PLUS, "+"},
+		{token.NUM, "1"},
+		{token.DOC_COMMENT, "যোগফল"},
+		{token.DOC_COMMENT, "  দুটি সংখ্যার"},
+		{token.COMMENT, " সাধারণ"},
+		{token.EOF, ""},
+	}
+
+	l := NewLexer(inp)
+
+	for i, tt := range tests {
+		tk := l.NextToken()
+
+		if tk.Type != tt.expectedType || tk.Literal != tt.expectedLit {
+			t.Fatalf("tests[%d] -> Expected=%s %q, Got=%s %q", i, tt.expectedType, tt.expectedLit, tk.Type, tk.Literal)
+		}
+	}
+
+	l = NewLexer("ক\n  /* /* */ খ")
+	for tk := l.NextToken(); tk.Type != token.EOF; tk = l.NextToken() {
+	}
+
+	errors := l.Errors()
+	if len(errors) != 1 || errors[0].Key != "UNTERMINATED_COMMENT" ||
+		errors[0].Token.LineNo != 2 || errors[0].Token.Column != 3 {
+		t.Errorf("Expected an unterminated comment at 2:3, Got=%v", errors)
+	}
+}
+
+func TestNumbers(t *testing.T) {
+	tests := []struct {
+		input       string
+		expectedLit string
+		malformed   bool
+	}{
+		{"১_০০_০০০", "100000", false},
+		{"1_000.2_5", "1000.25", false},
+		{"1.5e10", "1.5e10", false},
+		{"১.৫E-১০", "1.5e-10", false},
+		{"2e+3", "2e+3", false},
+		{"0x1F", "0x1F", false},
+		{"০xফ", "0", true},
+		{"০x১f", "0x1f", false},
+		{"0b1010", "0b1010", false},
+		{"০b১০_১০", "0b1010", false},
+		{"0b102", "0", true},
+		{"0x", "0", true},
+		{"1__000", "0", true},
+		{"1000_", "0", true},
+		{"1._5", "0", true},
+		{"1e", "0", true},
+		{"১০ক", "0", true},
+	}
+
+	for i, tt := range tests {
+		l := NewLexer(tt.input)
+		tk := l.NextToken()
+
+		if tk.Type != token.NUM || tk.Literal != tt.expectedLit {
+			t.Errorf("tests[%d] -> Expected=%q, Got=%s %q", i, tt.expectedLit, tk.Type, tk.Literal)
+		}
+
+		if next := l.NextToken(); next.Type != token.EOF {
+			t.Errorf("tests[%d] -> Expected EOF after number, Got=%s %q", i, next.Type, next.Literal)
+		}
+
+		if malformed := len(l.Errors()) == 1 && l.Errors()[0].Key == "MALFORMED_NUMBER"; malformed != tt.malformed {
+			t.Errorf("tests[%d] -> Expected malformed=%t, Got errors %v", i, tt.malformed, l.Errors())
+		}
+
+		if text := tt.input[tk.Span.Start.Offset:tk.Span.End.Offset]; text != tt.input {
+			t.Errorf("tests[%d] -> span wrong -> Got=%q", i, text)
+		}
+	}
+}
+
+func TestIdentifiers(t *testing.T) {
+	tests := []struct {
+		input       string
+		expectedTyp token.TokenType
+		expectedLit string
+	}{
+		// র‍্যাব, with a zero width joiner
+		{"\u09b0\u200d\u09cd\u09af\u09be\u09ac", token.IDENT, "\u09b0\u200d\u09cd\u09af\u09be\u09ac"},
+		// অক্‌ষর, with a zero width non joiner
+		{"\u0985\u0995\u09cd\u200c\u09b7\u09b0", token.IDENT, "\u0985\u0995\u09cd\u200c\u09b7\u09b0"},
+		// ৰাজ্য, which starts with an Assamese letter
+		{"\u09f0\u09be\u099c\u09cd\u09af", token.IDENT, "\u09f0\u09be\u099c\u09cd\u09af"},
+		{"ক১২", token.IDENT, "ক১২"},
+		{"_নাম_2", token.IDENT, "_নাম_2"},
+		{"名前", token.IDENT, "名前"},
+		// the precomposed য় is read in NFC, as য and a nukta
+		{"\u09df", token.IDENT, "\u09af\u09bc"},
+		{"\u0986\u09a8\u09df\u09a8", token.INCLUDE, "\u0986\u09a8\u09af\u09bc\u09a8"},
+		{"\u0986\u09a8\u09af\u09bc\u09a8", token.INCLUDE, "\u0986\u09a8\u09af\u09bc\u09a8"},
+		// the joiners can not start an identifier
+		{"\u200dক", token.ILLEGAL, "\u200d"},
+		{"৳", token.ILLEGAL, "৳"},
+	}
+
+	for i, tt := range tests {
+		l := NewLexer(tt.input)
+		tk := l.NextToken()
+
+		if tk.Type != tt.expectedTyp || tk.Literal != tt.expectedLit {
+			t.Errorf("tests[%d] -> Expected=%s %q, Got=%s %q", i, tt.expectedTyp, tt.expectedLit, tk.Type, tk.Literal)
+		}
+	}
+}
+
+func TestPackDirective(t *testing.T) {
+	l := NewLexer("# ভাষা: অসমীয়া\nধৰা ক = সঁচা")
+
+	expected := []token.TokenType{token.COMMENT, token.LET, token.IDENT, token.EQ, token.TRUE, token.EOF}
+	for i, tt := range expected {
+		if tk := l.NextToken(); tk.Type != tt {
+			t.Errorf("tokens[%d] -> Expected=%s, Got=%s %q", i, tt, tk.Type, tk.Literal)
+		}
+	}
+
+	l = NewLexer("# pack: latin\ndhori x = 1")
+	if errs := l.Errors(); len(errs) != 1 || errs[0].Key != "UNKNOWN_PACK" || errs[0].Token.Column != 9 {
+		t.Errorf("expected UNKNOWN_PACK at column 9, got %v", errs)
+	}
+}
@@ -1,7 +1,6 @@
 package main_test
 
 import (
-	"bytes"
 	"testing"
 
 	"go.cs.palashbauri.in/pankti/evaluator"
@@ -43,9 +42,8 @@ func TestX(t *testing.T) {
 	p := parser.NewParser(&l)
 	prog := p.ParseProg()
 	eh := object.ErrorHelper{Source: src}
-	printBuf := new(bytes.Buffer)
 	env := object.NewEnvMap()
-	ev := evaluator.Eval(prog, env, eh, printBuf, true)
+	ev := evaluator.Eval(prog, env, eh)
 
 	got := ev.Inspect()
 
@@ -61,8 +59,7 @@ func BenchmarkX(b *testing.B) {
 		p := parser.NewParser(&l)
 		prog := p.ParseProg()
 		eh := object.ErrorHelper{Source: src}
-		printBuf := new(bytes.Buffer)
 		env := object.NewEnvMap()
-		evaluator.Eval(prog, env, eh, printBuf, true)
+		evaluator.Eval(prog, env, eh)
 	}
 }
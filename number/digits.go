@@ -0,0 +1,65 @@
+package number
+
+import "strings"
+
+// Digits is a set of digits which numbers are written with
+type Digits int
+
+const (
+	// 0123456789
+	ASCIIDigits Digits = iota
+	// ০১২৩৪৫৬৭৮৯
+	BengaliDigits
+)
+
+// Write replaces the ascii digits of s with the digits of d
+func (d Digits) Write(s string) string {
+	if d == BengaliDigits {
+		return ToBengaliDigits(s)
+	}
+
+	return s
+}
+
+// ParseDigits returns the digit set with the name s, which is "ascii"
+// or "bengali" (also "en", "bn" and "বাংলা")
+func ParseDigits(s string) (Digits, bool) {
+	switch strings.ToLower(s) {
+	case "ascii", "en", "english", "":
+		return ASCIIDigits, true
+	case "bengali", "bn", "bangla", "বাংলা":
+		return BengaliDigits, true
+	default:
+		return ASCIIDigits, false
+	}
+}
+
+// ASCIIDigit returns the ascii digit for a bengali digit, and any other
+// character as it is
+func ASCIIDigit(r rune) rune {
+	if '০' <= r && r <= '৯' {
+		return '0' + (r - '০')
+	}
+
+	return r
+}
+
+// BengaliDigit returns the bengali digit for an ascii digit, and any
+// other character as it is
+func BengaliDigit(r rune) rune {
+	if '0' <= r && r <= '9' {
+		return '০' + (r - '0')
+	}
+
+	return r
+}
+
+// ToASCIIDigits replaces the bengali digits of s with ascii digits
+func ToASCIIDigits(s string) string {
+	return strings.Map(ASCIIDigit, s)
+}
+
+// ToBengaliDigits replaces the ascii digits of s with bengali digits
+func ToBengaliDigits(s string) string {
+	return strings.Map(BengaliDigit, s)
+}
@@ -8,7 +8,7 @@ import (
 )
 
 func MakeInt(a int64) Number {
-	return Number{Value: &IntNumber{Value: *big.NewInt(a)}}
+	return Number{Value: &IntNumber{Value: *big.NewInt(a)}, IsInt: true}
 }
 
 func MakeFloat(a float64) Number {
@@ -283,3 +283,23 @@ func NumberOperation(op string, n Number, x Number) (Number, bool, bool) {
 		return Number{}, false, false
 	}
 }
+
+// Compare returns -1, 0 or 1 as a is less than, equal to or greater
+// than b
+func Compare(a Number, b Number) int {
+	ai, aInt := a.Value.(*IntNumber)
+	bi, bInt := b.Value.(*IntNumber)
+	if aInt && bInt {
+		return ai.Value.Cmp(&bi.Value)
+	}
+
+	return asBigFloat(a).Cmp(asBigFloat(b))
+}
+
+func asBigFloat(n Number) *big.Float {
+	if i, ok := n.Value.(*IntNumber); ok {
+		return new(big.Float).SetInt(&i.Value)
+	}
+
+	return &n.Value.(*FloatNumber).Value
+}
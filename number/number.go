@@ -18,7 +18,7 @@ type FloatNumber struct {
 }
 
 func (f *FloatNumber) String() string {
-	return string(f.Type()) + "->" + f.Value.String()
+	return f.Value.String()
 }
 
 func (*FloatNumber) Type() NumberType {
@@ -30,7 +30,7 @@ type IntNumber struct {
 }
 
 func (i *IntNumber) String() string {
-	return string(i.Type()) + "->" + i.Value.String()
+	return i.Value.String()
 }
 
 func (*IntNumber) Type() NumberType {
@@ -46,8 +46,26 @@ func (n *Number) String() string {
 	return fmt.Sprintf("%#v", n.Value)
 }
 
+// IsFloat reports if a number literal, as returned by the lexer, is a
+// decimal number such as 1.5 or 1e10
 func IsFloat(inp string) bool {
-	return strings.ContainsRune(inp, rune('.'))
+	if intBase(inp) != 10 {
+		return false
+	}
+
+	return strings.ContainsAny(inp, ".eE")
+}
+
+// intBase returns the base of an integer literal from its prefix
+func intBase(inp string) int {
+	switch {
+	case strings.HasPrefix(inp, "0x"):
+		return 16
+	case strings.HasPrefix(inp, "0b"):
+		return 2
+	default:
+		return 10
+	}
 }
 
 func (n *Number) SetValue(v string) bool {
@@ -62,8 +80,13 @@ func (n *Number) SetValue(v string) bool {
 
 		return noerr
 	} else {
+		base := intBase(v)
+		if base != 10 {
+			v = v[2:]
+		}
+
 		temp := new(big.Int)
-		i, noerr := temp.SetString(v, 10)
+		i, noerr := temp.SetString(v, base)
 
 		if noerr {
 			n.Value = &IntNumber{Value: *i}
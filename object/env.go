@@ -34,7 +34,8 @@ func NewEnclosedEnv(outer *Env) *Env {
 }
 
 type EnvMap struct {
-	Envs map[string]Env
+	Envs    map[string]Env
+	Runtime *Runtime
 }
 
 func NewEnvMap() *EnvMap {
@@ -43,6 +44,16 @@ func NewEnvMap() *EnvMap {
 	return &e
 }
 
+// GetRuntime returns the runtime of the environment; if none was set it
+// returns the default runtime
+func (em *EnvMap) GetRuntime() *Runtime {
+	if em.Runtime == nil {
+		return DefaultRuntime()
+	}
+
+	return em.Runtime
+}
+
 func (em *EnvMap) GetDefaultEnv() (Env, bool) {
 	e, ok := em.Envs[DEFKEY]
 	return e, ok
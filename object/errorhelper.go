@@ -2,73 +2,66 @@ package object
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
 
+	"go.cs.palashbauri.in/pankti/diag"
+	"go.cs.palashbauri.in/pankti/errs"
+	"go.cs.palashbauri.in/pankti/number"
+	"go.cs.palashbauri.in/pankti/pack"
 	"go.cs.palashbauri.in/pankti/token"
 )
 
+// ErrorHelper makes error messages which show where in the source code
+// the error happened
 type ErrorHelper struct {
 	Source string
+	// color the messages for a terminal
+	Color bool
+	// the digits numbers in the messages are written with
+	Digits number.Digits
+	// the keyword pack of the program, which gives the messages and the
+	// names of types; nil for the Bengali pack
+	Pack *pack.Pack
+	// the call of the builtin which is running; errors at a token without
+	// a place in the source, such as of a value made by the VM, show it
+	Caller token.Token
 }
 
-func (e *ErrorHelper) GetLine(t token.Token) string {
+// Msg returns the error message called key, from the pack of eh
+func (e *ErrorHelper) Msg(key string) string {
+	if e == nil {
+		return errs.Errs[key]
+	}
 
-	return strings.Split(e.Source, "\n")[t.LineNo-1]
+	return e.Pack.Msg(key)
 }
 
-func (e *ErrorHelper) MakeErrorLine(t token.Token, showHint bool) string {
-
-	if t.LineNo <= 0 { //if the token is virtual token; line can be zero
-		return ""
+// TypeName returns the name of the object type t in the pack of eh
+func (e *ErrorHelper) TypeName(t ObjType) string {
+	var p *pack.Pack
+	if e != nil {
+		p = e.Pack
 	}
 
-	//newLine := e.Source
-	//var newLine string
-	xLine := e.GetLine(t)
-	//fmt.Println(xLine)
-	if showHint {
-
-		x := []rune(xLine)
-		var li int
-		var ri int
-
-		if temp := t.Column + len([]rune(t.Literal)); temp < len(x) {
-			ri = temp
-		} else {
-			ri = len(x) - 1
-		}
-
-		//fmt.Println(xLine)
-
-		//Lindex := t.Column - 1
-		//fmt.Println(t.Column)
-		if li < 0 { //In case of a virtual token
-			li = 0
-			//return ""
-		}
-
-		//RIndex := t.Column + len(t.Literal) - 1
-
-		if len(t.Literal) <= 1 {
-			ri = li + 1
-		}
-
-		li = t.Column
-
-		temp_x := string(x[:ri]) + " <-- " + string(x[ri:])
-		y := []rune(temp_x)
-		temp_y := string(y[:li]) + " --> " + string(y[li:])
-		//fmt.Println(temp_y)
+	return typeName(p, t)
+}
 
-		//newL := xLine[:RIndex] + " <-- " + xLine[RIndex:]
-		//newLine = newL[:Lindex] + " --> " + newL[Lindex:]
+// Render renders a diagnostic about the source
+func (e *ErrorHelper) Render(d diag.Diagnostic) string {
+	return diag.Renderer{Color: e.Color, HintPrefix: e.Msg("HINT_PREFIX")}.Render(e.Source, d)
+}
 
-		return strconv.Itoa(t.LineNo) + "| " + temp_y
+// Sprintf formats an error message, writing the numbers in it with the
+// digits of eh
+func (e *ErrorHelper) Sprintf(format string, a ...interface{}) string {
+	if e != nil {
+		return Sprintf(e.Digits, format, a...)
 	}
-	return strconv.Itoa(t.LineNo) + "| " + xLine
+
+	return fmt.Sprintf(format, a...)
 }
 
+// NewErr makes an error with the message format and shows the line of the
+// token before it. If showHint is true, the token is also marked
 func NewErr(
 	token token.Token,
 	eh *ErrorHelper,
@@ -77,13 +70,29 @@ func NewErr(
 	a ...interface{},
 ) *Error {
 
-	errMsg := eh.MakeErrorLine(
-		token,
-		showHint,
-	) + "\n" + fmt.Sprintf(
-		format,
-		a...)
-	return &Error{Msg: errMsg}
+	if token.LineNo == 0 && eh != nil {
+		token = eh.Caller
+	}
+
+	label := diag.At(token, "")
+	if !showHint {
+		label.Col = 0
+	}
+
+	return NewDiagErr(eh, diag.Diagnostic{
+		Msg:     eh.Sprintf(format, a...),
+		Primary: label,
+	})
+}
+
+// NewDiagErr makes an error out of a diagnostic, for errors which need
+// more than a marked token, such as a secondary label or a hint
+func NewDiagErr(eh *ErrorHelper, d diag.Diagnostic) *Error {
+	if eh == nil {
+		eh = &ErrorHelper{}
+	}
+
+	return &Error{Msg: eh.Render(d)}
 }
 
 func IsErr(obj Obj) bool {
@@ -97,3 +106,9 @@ func IsErr(obj Obj) bool {
 func NewBareErr(format string, a ...interface{}) Obj {
 	return &Error{Msg: fmt.Sprintf(format, a...)}
 }
+
+// WrapErr makes an error of the program out of an error of the host, such
+// as a *LimitError, which the host can still find with errors.As
+func WrapErr(err error) *Error {
+	return &Error{Msg: err.Error(), Err: err}
+}
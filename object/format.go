@@ -0,0 +1,106 @@
+package object
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"go.cs.palashbauri.in/pankti/number"
+	"go.cs.palashbauri.in/pankti/token"
+)
+
+// Format returns o as dekhau shows it, with the numbers in it written
+// with digits
+func Format(o Obj, digits number.Digits) string {
+	if digits == number.ASCIIDigits {
+		return o.Inspect()
+	}
+
+	switch o := o.(type) {
+	case *Number:
+		return digits.Write(o.Inspect())
+	case *Array:
+		es := []string{}
+		for _, e := range o.Elms {
+			es = append(es, Format(e, digits))
+		}
+
+		return "[" + strings.Join(es, ", ") + "]"
+	case *Hash:
+		pairs := []string{}
+		for _, p := range o.Pairs {
+			pairs = append(pairs, Format(p.Key, digits)+" : "+Format(p.Value, digits))
+		}
+
+		return "{" + strings.Join(pairs, ", ") + "}"
+	case *ReturnValue:
+		return Format(o.Value, digits)
+	default:
+		return o.Inspect()
+	}
+}
+
+// Format returns o as dekhau shows it, with the digits of the runtime
+func (rt *Runtime) Format(o Obj) string {
+	return Format(o, rt.Digits)
+}
+
+// Concat joins objs into a string, each written as dekhau shows it
+func (rt *Runtime) Concat(objs []Obj, tok token.Token) *String {
+	out := strings.Builder{}
+	for _, o := range objs {
+		out.WriteString(rt.Format(o))
+	}
+
+	return &String{Value: out.String(), Token: tok}
+}
+
+// digitsArg writes a number passed to an error message with a digit set
+type digitsArg struct {
+	v      interface{}
+	digits number.Digits
+}
+
+func (a digitsArg) Format(f fmt.State, verb rune) {
+	format := "%"
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			format += string(flag)
+		}
+	}
+	if w, ok := f.Width(); ok {
+		format += strconv.Itoa(w)
+	}
+	if p, ok := f.Precision(); ok {
+		format += "." + strconv.Itoa(p)
+	}
+
+	io.WriteString(f, a.digits.Write(fmt.Sprintf(format+string(verb), a.v)))
+}
+
+// Sprintf is like fmt.Sprintf but writes the numbers among a, including
+// *Number, with digits
+func Sprintf(digits number.Digits, format string, a ...interface{}) string {
+	return fmt.Sprintf(format, withDigits(digits, a)...)
+}
+
+// withDigits wraps the numbers among the values for an error message, so
+// that they are written with digits
+func withDigits(digits number.Digits, a []interface{}) []interface{} {
+	if digits == number.ASCIIDigits {
+		return a
+	}
+
+	out := make([]interface{}, len(a))
+	for i, v := range a {
+		switch v := v.(type) {
+		case int, int64, int32, uint, uint64, float64, *Number:
+			out[i] = digitsArg{v: v, digits: digits}
+		default:
+			out[i] = v
+		}
+	}
+
+	return out
+}
@@ -5,6 +5,7 @@ import "go.cs.palashbauri.in/pankti/number"
 func MakeIntNumber(i int64) Obj {
 	return &Number{
 		Value: number.MakeInt(i),
+		IsInt: true,
 	}
 }
 
@@ -25,6 +25,7 @@ const (
 	BREAK_OBJ         = "BREAK"
 	COMPILED_FUNC_OBJ = "COMPILED_FUNC_OBJ"
 	CLOSURE_OBJ       = "CLOSURE"
+	REGEX_OBJ         = "REGEX"
 )
 
 type BuiltInFunc func(eh *ErrorHelper, env *EnvMap, caller token.Token, args ...Obj) Obj
@@ -52,6 +53,13 @@ type CompiledFunc struct {
 	Instructions code.Instructions
 	NumLocals    int
 	NumParams    int
+	// for help(); unknown for anonymous functions
+	Name   string
+	Params []string
+	Doc    string
+	// the source tokens of the instructions which can fail, by their
+	// offset, for showing where an error happened
+	Tokens map[int]token.Token
 }
 
 func (*CompiledFunc) Type() ObjType { return COMPILED_FUNC_OBJ }
@@ -63,8 +71,26 @@ func (*CompiledFunc) GetToken() token.Token { return token.Token{} }
 type Builtin struct {
 	Fn    BuiltInFunc
 	Token token.Token
+	Sig   *Signature
 }
 
-func (*Builtin) Type() ObjType           { return BUILTIN_OBJ }
-func (*Builtin) Inspect() string         { return "builtin function" }
+// Call validates the arguments against the signature of the builtin
+// (if it has one) and then calls the builtin
+func (b *Builtin) Call(eh *ErrorHelper, env *EnvMap, caller token.Token, args ...Obj) Obj {
+	if b.Sig != nil {
+		if err := b.Sig.Check(eh, caller, args); err != nil {
+			return err
+		}
+	}
+
+	return b.Fn(eh, env, caller, args...)
+}
+
+func (*Builtin) Type() ObjType { return BUILTIN_OBJ }
+func (b *Builtin) Inspect() string {
+	if b.Sig != nil {
+		return "builtin function " + b.Sig.String()
+	}
+	return "builtin function"
+}
 func (b *Builtin) GetToken() token.Token { return b.Token }
@@ -0,0 +1,180 @@
+package object
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.cs.palashbauri.in/pankti/vfs"
+)
+
+// FSAccess is how much of the filesystem a program can use
+type FSAccess int
+
+const (
+	// FSReadWrite allows reading and changing files; it is the default
+	FSReadWrite FSAccess = iota
+	// FSReadOnly allows reading files and directories but not changing them
+	FSReadOnly
+	// FSNone denies any use of the filesystem, including `anoyon` of files
+	FSNone
+)
+
+// Permissions restrict what the standard library lets a program do on the
+// machine it runs on. The zero value allows everything
+type Permissions struct {
+	FS FSAccess
+	// if set, relative paths are resolved against FSRoot and paths outside
+	// of it can not be used
+	FSRoot string
+	// deny the `sys` module functions, which tell about the operating
+	// system and the user
+	NoOSInfo bool
+	// deny reading from the stdin
+	NoStdin bool
+}
+
+// Sandboxed returns permissions which deny all access to the machine
+func Sandboxed() Permissions {
+	return Permissions{FS: FSNone, NoOSInfo: true, NoStdin: true}
+}
+
+// PermissionError is returned when a program does something its
+// permissions do not allow
+type PermissionError struct {
+	Msg string
+}
+
+func (e *PermissionError) Error() string { return e.Msg }
+
+// ResolvePath returns the path a program should use for the file `path`,
+// or an error if the permissions do not allow using it. `write` must be
+// true if the file is going to be created, changed or deleted
+func (rt *Runtime) ResolvePath(path string, write bool) (string, error) {
+	p := rt.Perms
+
+	switch {
+	case p.FS == FSNone:
+		return "", &PermissionError{Msg: rt.Pack.Msg("PERM_NO_FS")}
+	case write && p.FS == FSReadOnly:
+		return "", &PermissionError{Msg: fmt.Sprintf(rt.Pack.Msg("PERM_READ_ONLY"), path)}
+	}
+
+	if len(p.FSRoot) == 0 {
+		return path, nil
+	}
+
+	root, err := rt.rootDir()
+	if err != nil {
+		return "", err
+	}
+
+	full := path
+	if !filepath.IsAbs(full) {
+		full = filepath.Join(root, full)
+	}
+	full = filepath.Clean(full)
+
+	if !isWithin(root, full) {
+		return "", &PermissionError{Msg: fmt.Sprintf(rt.Pack.Msg("PERM_OUTSIDE_ROOT"), path)}
+	}
+
+	// a symbolic link inside of the root must not lead outside of it
+	if fsys, ok := rt.FileSystem().(vfs.SymlinkFS); ok && !linksWithin(fsys, root, full) {
+		return "", &PermissionError{Msg: fmt.Sprintf(rt.Pack.Msg("PERM_OUTSIDE_ROOT"), path)}
+	}
+
+	return full, nil
+}
+
+// linksWithin tells if the path, after following its symbolic links, is
+// still inside of root. A file which does not exist yet is checked by
+// the deepest of its directories which does, since a link among them
+// would take the new file outside of root
+func linksWithin(fsys vfs.SymlinkFS, root, path string) bool {
+	realRoot, err := fsys.EvalSymlinks(root)
+	if err != nil {
+		return false
+	}
+
+	for {
+		real, err := fsys.EvalSymlinks(path)
+		if err == nil {
+			return isWithin(realRoot, real)
+		}
+
+		parent := filepath.Dir(path)
+		if !errors.Is(err, fs.ErrNotExist) || parent == path {
+			return false
+		}
+
+		path = parent
+	}
+}
+
+// RelPath turns a path returned by ResolvePath back into the path the
+// program knows it by, so that the location of FSRoot is not revealed
+func (rt *Runtime) RelPath(path string) string {
+	if len(rt.Perms.FSRoot) == 0 {
+		return path
+	}
+
+	root, err := rt.rootDir()
+	if err != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return path
+	}
+
+	return rel
+}
+
+// IsRoot tells if path, as returned by ResolvePath, is FSRoot itself,
+// which the program must not delete or move
+func (rt *Runtime) IsRoot(path string) bool {
+	if len(rt.Perms.FSRoot) == 0 {
+		return false
+	}
+
+	root, err := rt.rootDir()
+	return err == nil && filepath.Clean(path) == filepath.Clean(root)
+}
+
+// rootDir returns FSRoot as an absolute path. On filesystems other than
+// the one of the operating system relative paths start from "/"
+func (rt *Runtime) rootDir() (string, error) {
+	if vfs.IsOS(rt.FileSystem()) {
+		return filepath.Abs(rt.Perms.FSRoot)
+	}
+
+	return filepath.Join(string(os.PathSeparator), rt.Perms.FSRoot), nil
+}
+
+func isWithin(root, path string) bool {
+	return path == root || strings.HasPrefix(path, strings.TrimSuffix(root, string(os.PathSeparator))+string(os.PathSeparator))
+}
+
+// CheckOSInfo returns an error if the program can not ask about the
+// operating system and the user
+func (rt *Runtime) CheckOSInfo() error {
+	if rt.Perms.NoOSInfo {
+		return &PermissionError{Msg: rt.Pack.Msg("PERM_NO_OS_INFO")}
+	}
+
+	return nil
+}
+
+// CheckStdin returns an error if the program can not read from the stdin
+func (rt *Runtime) CheckStdin() error {
+	if rt.Perms.NoStdin {
+		return &PermissionError{Msg: rt.Pack.Msg("PERM_NO_STDIN")}
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package object
+
+import (
+	"fmt"
+	"regexp"
+
+	"go.cs.palashbauri.in/pankti/token"
+)
+
+// Regex is a compiled regular expression of the regex module
+type Regex struct {
+	Re    *regexp.Regexp
+	Token token.Token
+}
+
+func (*Regex) Type() ObjType { return REGEX_OBJ }
+func (r *Regex) Inspect() string {
+	return fmt.Sprintf("/%s/", r.Re.String())
+}
+func (r *Regex) GetToken() token.Token { return r.Token }
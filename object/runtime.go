@@ -0,0 +1,280 @@
+package object
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"go.cs.palashbauri.in/pankti/number"
+	"go.cs.palashbauri.in/pankti/pack"
+	"go.cs.palashbauri.in/pankti/token"
+	"go.cs.palashbauri.in/pankti/vfs"
+)
+
+// DefaultMaxCallDepth is the call depth limit used when Limits does not
+// set one. Deep recursion would otherwise overflow the Go stack, which
+// cannot be recovered from
+const DefaultMaxCallDepth = 10000
+
+// how many steps are taken between checks of the context
+const ctxCheckInterval = 1024
+
+// Limits restricts how much a program can do. Zero values mean no limit,
+// except for MaxCallDepth which then uses DefaultMaxCallDepth
+type Limits struct {
+	// evaluation steps for the evaluator or instructions for the vm
+	MaxSteps int64
+	// nested function calls
+	MaxCallDepth int
+	// approximate total size in bytes of the strings, arrays and hashes
+	// created by the program
+	MaxAlloc int64
+}
+
+// LimitError is the error returned when a program exceeds one of its
+// limits or its context is done
+type LimitError struct {
+	Msg string
+}
+
+func (e *LimitError) Error() string { return e.Msg }
+
+// ModuleLoader returns the source code of the module which is included
+// with `anoyon name`
+type ModuleLoader func(name string) (string, bool)
+
+// LineReader asks the host for a line of input, such as from a text box
+// in an app; prompt is the message the program shows to the user
+type LineReader func(prompt string) (string, error)
+
+// FuncCaller calls a function of the program with args, such as one given
+// to a builtin as a callback; caller is where the builtin was called
+type FuncCaller func(eh *ErrorHelper, caller token.Token, fn Obj, args []Obj) Obj
+
+// Runtime holds everything a running program gets from its host,
+// such as where the output of `dekhau` goes
+type Runtime struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	Stdin  io.Reader
+	// if set, programs read their input from Input instead of Stdin
+	Input  LineReader
+	Loader ModuleLoader
+	// the filesystem used by the file module and to load modules; nil
+	// means the filesystem of the operating system
+	FS vfs.FS
+
+	// modules defined by the host, by name and then by member name
+	HostModules map[string]map[string]Obj
+
+	// the program stops with an error once Ctx is done
+	Ctx    context.Context
+	Limits Limits
+	Perms  Permissions
+	// the digits numbers are shown with
+	Digits number.Digits
+	// the keyword pack of the program, which gives the error messages;
+	// nil for the Bengali pack
+	Pack *pack.Pack
+	// set by the evaluator or the vm which runs the program
+	Caller FuncCaller
+
+	stdinReader *bufio.Reader
+	steps       int64
+	depth       int
+	alloc       int64
+}
+
+// Reset clears the counters used for the limits, before a new run
+func (rt *Runtime) Reset() {
+	rt.steps = 0
+	rt.depth = 0
+	rt.alloc = 0
+}
+
+// Step counts one step of the program and checks the step limit and
+// the context
+func (rt *Runtime) Step() error {
+	if rt.Ctx == nil && rt.Limits.MaxSteps == 0 {
+		return nil
+	}
+
+	rt.steps++
+
+	if rt.Limits.MaxSteps > 0 && rt.steps > rt.Limits.MaxSteps {
+		return &LimitError{Msg: fmt.Sprintf(rt.Pack.Msg("STEP_LIMIT_EXCEEDED"), rt.Limits.MaxSteps)}
+	}
+
+	if rt.Ctx != nil && rt.steps%ctxCheckInterval == 0 {
+		return rt.checkCtx()
+	}
+
+	return nil
+}
+
+// checkCtx also compares the deadline directly, as the context may not
+// get the chance to be cancelled by its timer while the program is
+// running (for example on wasm)
+func (rt *Runtime) checkCtx() error {
+	err := rt.Ctx.Err()
+
+	if d, ok := rt.Ctx.Deadline(); err == nil && ok && time.Now().After(d) {
+		err = context.DeadlineExceeded
+	}
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, context.DeadlineExceeded):
+		return &LimitError{Msg: rt.Pack.Msg("TIMEOUT")}
+	default:
+		return &LimitError{Msg: rt.Pack.Msg("CANCELLED")}
+	}
+}
+
+// EnterCall must be called before calling a function and checks the
+// call depth limit; LeaveCall must be called after the function returns
+func (rt *Runtime) EnterCall() error {
+	if err := rt.CheckCallDepth(rt.depth + 1); err != nil {
+		return err
+	}
+
+	rt.depth++
+	return nil
+}
+
+// CheckCallDepth checks the call depth limit for a call which would be
+// nested `depth` levels deep
+func (rt *Runtime) CheckCallDepth(depth int) error {
+	max := rt.Limits.MaxCallDepth
+	if max <= 0 {
+		max = DefaultMaxCallDepth
+	}
+
+	if depth > max {
+		return rt.CallDepthError(max)
+	}
+
+	return nil
+}
+
+// CallDepthError is the error of a call nested deeper than max
+func (rt *Runtime) CallDepthError(max int) error {
+	return &LimitError{Msg: fmt.Sprintf(rt.Pack.Msg("CALL_DEPTH_EXCEEDED"), max)}
+}
+
+func (rt *Runtime) LeaveCall() {
+	rt.depth--
+}
+
+// Call calls fn, a function of the program or a builtin, with args; a
+// function which returns nothing returns nil
+func (rt *Runtime) Call(eh *ErrorHelper, caller token.Token, fn Obj, args []Obj) Obj {
+	if rt.Caller == nil {
+		if b, ok := fn.(*Builtin); ok {
+			return b.Call(eh, &EnvMap{Runtime: rt}, caller, args...)
+		}
+
+		return NewErr(caller, eh, true, eh.Msg("CALLBACK_NOT_CALLABLE"), fn.Inspect())
+	}
+
+	if result := rt.Caller(eh, caller, fn, args); result != nil {
+		return result
+	}
+
+	return &Null{}
+}
+
+// Alloc counts the size of a newly created object and checks the
+// allocation limit
+func (rt *Runtime) Alloc(obj Obj) error {
+	if rt.Limits.MaxAlloc == 0 {
+		return nil
+	}
+
+	rt.alloc += SizeOf(obj)
+
+	if rt.alloc > rt.Limits.MaxAlloc {
+		return &LimitError{Msg: fmt.Sprintf(rt.Pack.Msg("ALLOC_LIMIT_EXCEEDED"), rt.Limits.MaxAlloc)}
+	}
+
+	return nil
+}
+
+// CheckAlloc checks if an object of size bytes would still fit in the
+// allocation limit, without counting it, so that a builtin can fail
+// before it builds a large object
+func (rt *Runtime) CheckAlloc(size int64) error {
+	if rt.Limits.MaxAlloc == 0 {
+		return nil
+	}
+
+	if size < 0 || size > rt.Limits.MaxAlloc-rt.alloc {
+		return &LimitError{Msg: fmt.Sprintf(rt.Pack.Msg("ALLOC_LIMIT_EXCEEDED"), rt.Limits.MaxAlloc)}
+	}
+
+	return nil
+}
+
+// SizeOf returns the approximate size of a string, array or hash in
+// bytes, without the objects it contains. Other values, such as numbers,
+// are not counted
+func SizeOf(obj Obj) int64 {
+	switch obj := obj.(type) {
+	case *String:
+		return int64(len(obj.Value)) + 16
+	case *Array:
+		return int64(len(obj.Elms))*16 + 24
+	case *Hash:
+		return int64(len(obj.Pairs))*48 + 48
+	default:
+		return 0
+	}
+}
+
+var defaultRuntime = &Runtime{
+	Stdout: os.Stdout,
+	Stderr: os.Stderr,
+	Stdin:  os.Stdin,
+}
+
+// DefaultRuntime returns the runtime which uses standard input and
+// output of the process
+func DefaultRuntime() *Runtime {
+	return defaultRuntime
+}
+
+// FileSystem returns the filesystem programs use
+func (rt *Runtime) FileSystem() vfs.FS {
+	if rt.FS == nil {
+		return vfs.OS{}
+	}
+
+	return rt.FS
+}
+
+// ReadLine shows prompt and reads a single line (without the line ending)
+// from the Input of the runtime, or from its Stdin if there is no Input
+func (rt *Runtime) ReadLine(prompt string) (string, error) {
+	if rt.Input != nil {
+		return rt.Input(prompt)
+	}
+
+	fmt.Fprint(rt.Stdout, prompt)
+
+	if rt.stdinReader == nil {
+		rt.stdinReader = bufio.NewReader(rt.Stdin)
+	}
+
+	text, err := rt.stdinReader.ReadString('\n')
+	if err != nil && (err != io.EOF || len(text) == 0) {
+		return "", err
+	}
+
+	return strings.TrimRight(text, "\r\n"), nil
+}
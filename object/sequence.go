@@ -0,0 +1,94 @@
+package object
+
+import (
+	"strings"
+
+	"go.cs.palashbauri.in/pankti/grapheme"
+	"go.cs.palashbauri.in/pankti/number"
+)
+
+// Strings are indexed, sliced and measured in grapheme clusters, the
+// letters a reader sees, so that "ক্ষমা"[0] is "ক্ষ" and not "ক"
+
+// AsIndex returns the integer value of an index or a bound of a slice;
+// it is false for other values, including fractions
+func AsIndex(o Obj) (int64, bool) {
+	n, ok := o.(*Number)
+	if !ok || !n.Value.IsInt {
+		return 0, false
+	}
+
+	return number.GetAsInt(n.Value)
+}
+
+// ElementAt returns the element of an array, or the grapheme cluster of
+// a string, at i; it is false when i is out of range
+func ElementAt(o Obj, i int64) (Obj, bool) {
+	switch o := o.(type) {
+	case *Array:
+		if i < 0 || i >= int64(len(o.Elms)) {
+			return nil, false
+		}
+
+		return o.Elms[i], true
+	case *String:
+		clusters := grapheme.Clusters(o.Value)
+		if i < 0 || i >= int64(len(clusters)) {
+			return nil, false
+		}
+
+		return &String{Value: clusters[i], Token: o.Token}, true
+	}
+
+	return nil, false
+}
+
+// SliceOf returns the part of an array, or of a string counted in
+// grapheme clusters, from start up to but not including end. A nil
+// bound means the beginning or the end, and bounds out of range are
+// moved inside of it
+func SliceOf(o Obj, start, end *int64) Obj {
+	switch o := o.(type) {
+	case *Array:
+		from, to := bounds(len(o.Elms), start, end)
+		elms := make([]Obj, to-from)
+		copy(elms, o.Elms[from:to])
+		return &Array{Elms: elms, Token: o.Token}
+	case *String:
+		clusters := grapheme.Clusters(o.Value)
+		from, to := bounds(len(clusters), start, end)
+
+		return &String{Value: strings.Join(clusters[from:to], ""), Token: o.Token}
+	}
+
+	return nil
+}
+
+func bounds(length int, start, end *int64) (int, int) {
+	from, to := int64(0), int64(length)
+	if start != nil {
+		from = *start
+	}
+	if end != nil {
+		to = *end
+	}
+
+	from = clamp(from, int64(length))
+	to = clamp(to, int64(length))
+	if to < from {
+		to = from
+	}
+
+	return int(from), int(to)
+}
+
+func clamp(i, length int64) int64 {
+	if i < 0 {
+		return 0
+	}
+	if i > length {
+		return length
+	}
+
+	return i
+}
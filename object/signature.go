@@ -0,0 +1,121 @@
+package object
+
+import (
+	"strings"
+
+	"go.cs.palashbauri.in/pankti/constants"
+	"go.cs.palashbauri.in/pankti/pack"
+	"go.cs.palashbauri.in/pankti/token"
+)
+
+// Param describes a single parameter of a builtin function.
+// Empty Types means the parameter accepts a value of any type
+type Param struct {
+	Name  string
+	Types []ObjType
+}
+
+func (p Param) accepts(t ObjType) bool {
+	if len(p.Types) == 0 {
+		return true
+	}
+
+	for _, pt := range p.Types {
+		if pt == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p Param) typeNames(eh *ErrorHelper) string {
+	if len(p.Types) == 0 {
+		return ""
+	}
+
+	names := []string{}
+	for _, t := range p.Types {
+		names = append(names, eh.TypeName(t))
+	}
+
+	return strings.Join(names, "/")
+}
+
+// Signature describes how a builtin function can be called. If Variadic
+// is true, the last parameter can be repeated any number of times
+// (including zero times)
+type Signature struct {
+	Name     string
+	Params   []Param
+	Variadic bool
+}
+
+// Check validates the arguments of a call to a builtin with this
+// signature. It returns nil if all the arguments are acceptable
+func (s *Signature) Check(eh *ErrorHelper, caller token.Token, args []Obj) *Error {
+	n := len(s.Params)
+
+	if s.Variadic {
+		if len(args) < n-1 {
+			return NewErr(caller, eh, false, eh.Msg("FUN_CALL_AT_LEAST_ARGS"), s.Name, n-1, len(args))
+		}
+	} else if len(args) != n {
+		return NewErr(caller, eh, false, eh.Msg("FUN_CALL_NOT_ENOUGH_ARGS"), s.Name, n, len(args))
+	}
+
+	for i, arg := range args {
+		p := s.Params[n-1]
+		if i < n {
+			p = s.Params[i]
+		}
+
+		if arg == nil || !p.accepts(arg.Type()) {
+			got := constants.UNKNOWN
+			if arg != nil {
+				got = eh.TypeName(arg.Type())
+			}
+
+			return NewErr(caller, eh, false, eh.Msg("ARG_TYPE_MISMATCH"), s.Name, p.Name, p.typeNames(eh), got)
+		}
+	}
+
+	return nil
+}
+
+// String returns the signature in a human readable form,
+// such as `পাওয়ার(ভিত্তি: সংখ্যা, ঘাত: সংখ্যা)`
+func (s *Signature) String() string {
+	params := []string{}
+
+	for i, p := range s.Params {
+		str := p.Name
+		if tn := p.typeNames(nil); len(tn) > 0 {
+			str += ": " + tn
+		}
+		if s.Variadic && i == len(s.Params)-1 {
+			str += "..."
+		}
+		params = append(params, str)
+	}
+
+	return s.Name + "(" + strings.Join(params, ", ") + ")"
+}
+
+// TypeName returns the bengali name of the object type
+func TypeName(t ObjType) string {
+	return typeName(nil, t)
+}
+
+func typeName(p *pack.Pack, t ObjType) string {
+	// the closures of the vm are the functions of the program
+	if t == CLOSURE_OBJ {
+		t = FUNC_OBJ
+	}
+
+	if name, ok := p.TypeName(string(t)); ok {
+		return name
+	}
+
+	return constants.UNKNOWN
+}
@@ -0,0 +1,37 @@
+package object
+
+import (
+	"testing"
+
+	"go.cs.palashbauri.in/pankti/token"
+)
+
+func TestSignatureCheck(t *testing.T) {
+	sig := &Signature{
+		Name:     "f",
+		Params:   []Param{{Name: "a", Types: []ObjType{NUM_OBJ}}, {Name: "b"}},
+		Variadic: true,
+	}
+	eh := &ErrorHelper{}
+
+	tests := []struct {
+		args []Obj
+		ok   bool
+	}{
+		{[]Obj{MakeIntNumber(1)}, true},
+		{[]Obj{MakeIntNumber(1), &String{Value: "x"}, &Null{}}, true},
+		{[]Obj{}, false},
+		{[]Obj{&String{Value: "x"}}, false},
+	}
+
+	for i, tt := range tests {
+		err := sig.Check(eh, token.Token{}, tt.args)
+		if (err == nil) != tt.ok {
+			t.Errorf("tests[%d] -> wrong result; wanted ok=%v, got err=%v", i, tt.ok, err)
+		}
+	}
+
+	if s := sig.String(); s != "f(a: সংখ্যা, b...)" {
+		t.Errorf("wrong signature string %q", s)
+	}
+}
@@ -15,6 +15,7 @@ type Function struct {
 	Body   *ast.BlockStmt
 	Env    *Env
 	Token  token.Token
+	Doc    string
 }
 
 func (*Function) Type() ObjType { return FUNC_OBJ }
@@ -88,8 +89,16 @@ func (r *ReturnValue) GetToken() token.Token { return r.Token }
 
 type Error struct {
 	Msg string
+	// the error of the host the program stopped for, such as a
+	// *LimitError; nil for the errors of the program itself
+	Err error
 }
 
 func (*Error) Type() ObjType         { return ERR_OBJ }
 func (e *Error) Inspect() string     { return "ERR : " + e.Msg }
 func (*Error) GetToken() token.Token { return token.Token{} }
+
+// Error and Unwrap let the vm return an error of the program as a Go
+// error, keeping what caused it
+func (e *Error) Error() string { return e.Msg }
+func (e *Error) Unwrap() error { return e.Err }
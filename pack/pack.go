@@ -0,0 +1,247 @@
+// Package pack loads keyword packs. A pack gives the spellings of the
+// keywords of Pankti in a language, along with the names of the tokens
+// and types and the error messages shown in that language, so that the
+// same language can be taught with Assamese or Kokborok keywords.
+//
+// The Bengali pack is built in and is made from the tables of the token,
+// errs and constants packages. Other packs are data files, which are
+// either built in (see the packs directory) or given by the user, and
+// start from the pack they name as their base:
+//
+//	# comments start with #
+//	name = assamese
+//	aliases = অসমীয়া, as
+//	base = bengali
+//
+//	[keywords]
+//	LET = ধৰা
+//	TRUE = সঁচা
+//
+//	[types]
+//	STRING = ষ্ট্ৰিং
+//
+//	[errors]
+//	INDEX_OUT_RANGE = এই সূচকটো তালিকাখনৰ দৈৰ্ঘ্যতকৈ ডাঙৰ।
+//
+// A pack is given to the lexer of a program, or chosen by a comment on
+// the first line of a file, such as `# ভাষা: অসমীয়া` or `# pack: assamese`.
+// The tables of the token, errs and constants packages are never
+// changed, so that programs with different packs can run at the same
+// time
+package pack
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"go.cs.palashbauri.in/pankti/constants"
+	"go.cs.palashbauri.in/pankti/errs"
+	"go.cs.palashbauri.in/pankti/token"
+)
+
+type Pack struct {
+	Name    string
+	Aliases []string
+	// spelling of a keyword to its token type, in NFC
+	Keywords map[string]token.TokenType
+	// token type to the name shown in error messages
+	HumanFriendly map[string]string
+	// object type to the name shown to the user
+	TypeNames map[string]string
+	// key of an error message to the message
+	Errors map[string]string
+}
+
+//go:embed packs/*.pack
+var builtin embed.FS
+
+// Default is the Bengali pack, which is used when no other pack is
+// selected
+var Default *Pack
+
+var packs = map[string]*Pack{}
+
+func init() {
+	Default = &Pack{
+		Name:          "bengali",
+		Aliases:       []string{"বাংলা", "bn"},
+		Keywords:      map[string]token.TokenType{},
+		HumanFriendly: copyMap(token.HumanFriendly),
+		TypeNames:     copyMap(constants.TypeNames),
+		Errors:        copyMap(errs.Errs),
+	}
+
+	for kw, t := range token.Keywords {
+		Default.Keywords[kw] = t
+	}
+
+	Register(Default)
+
+	files, _ := builtin.ReadDir("packs")
+	for _, f := range files {
+		data, err := builtin.ReadFile("packs/" + f.Name())
+		if err != nil {
+			panic(err)
+		}
+
+		p, err := Parse(string(data))
+		if err != nil {
+			panic(fmt.Sprintf("pack %s: %s", f.Name(), err))
+		}
+
+		Register(p)
+	}
+}
+
+// Register makes a pack available by its name and its aliases, for Get
+// and for the first line comments of files
+func Register(p *Pack) {
+	packs[key(p.Name)] = p
+	for _, alias := range p.Aliases {
+		packs[key(alias)] = p
+	}
+}
+
+// Get finds a registered pack by its name or by one of its aliases
+func Get(name string) (*Pack, bool) {
+	p, ok := packs[key(name)]
+	return p, ok
+}
+
+// Names returns the names of all registered packs
+func Names() []string {
+	names := []string{}
+	seen := map[*Pack]bool{}
+
+	for _, p := range packs {
+		if !seen[p] {
+			seen[p] = true
+			names = append(names, p.Name)
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// Find returns the registered pack called name, or else reads, registers
+// and returns the pack file at the path name
+func Find(name string) (*Pack, error) {
+	if p, ok := Get(name); ok {
+		return p, nil
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown keyword pack `%s`; the packs are %s", name, strings.Join(Names(), ", "))
+	}
+
+	p, err := Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	Register(p)
+	return p, nil
+}
+
+// Msg returns the error message called key; a nil pack is the Bengali
+// one
+func (p *Pack) Msg(key string) string {
+	if p != nil {
+		if msg, ok := p.Errors[key]; ok {
+			return msg
+		}
+	}
+
+	return errs.Errs[key]
+}
+
+// TypeName returns the name of the object type t, if it has one
+func (p *Pack) TypeName(t string) (string, bool) {
+	if p == nil {
+		p = Default
+	}
+
+	name, ok := p.TypeNames[t]
+	return name, ok
+}
+
+// TokenName returns the name of the token type t shown in error
+// messages, or t itself if it has none
+func (p *Pack) TokenName(t token.TokenType) string {
+	if p == nil {
+		p = Default
+	}
+
+	if name, ok := p.HumanFriendly[string(t)]; ok {
+		return name
+	}
+
+	return string(t)
+}
+
+// LookupIdent is like token.LookupIdent but with the keywords of p
+func (p *Pack) LookupIdent(ident string) token.TokenType {
+	if tok, ok := p.Keywords[ident]; ok {
+		return tok
+	}
+
+	return token.IDENT
+}
+
+// directiveNames are the words which select a pack in the comment on the
+// first line of a file
+var directiveNames = []string{"pack", "ভাষা"}
+
+// Directive finds the pack named by the comment on the first line of
+// src, such as `# ভাষা: অসমীয়া`. It also returns the byte offset of the
+// name in src
+func Directive(src string) (string, int, bool) {
+	line := src
+	if i := strings.IndexByte(src, '\n'); i >= 0 {
+		line = src[:i]
+	}
+
+	rest := strings.TrimLeft(line, " \t")
+	if !strings.HasPrefix(rest, "#") || strings.HasPrefix(rest, "##") {
+		return "", 0, false
+	}
+	rest = strings.TrimLeft(rest[1:], " \t")
+
+	for _, d := range directiveNames {
+		if !strings.HasPrefix(rest, d) {
+			continue
+		}
+
+		value := strings.TrimLeft(rest[len(d):], " \t")
+		if !strings.HasPrefix(value, ":") {
+			continue
+		}
+
+		name := strings.TrimSpace(value[1:])
+		if len(name) == 0 {
+			return "", 0, false
+		}
+
+		return name, strings.Index(line, name), true
+	}
+
+	return "", 0, false
+}
+
+func key(name string) string {
+	return strings.ToLower(token.Normalize(strings.TrimSpace(name)))
+}
+
+func copyMap(m map[string]string) map[string]string {
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+
+	return result
+}
@@ -0,0 +1,111 @@
+package pack
+
+import (
+	"strings"
+	"testing"
+
+	"go.cs.palashbauri.in/pankti/token"
+)
+
+func TestBuiltinPacks(t *testing.T) {
+	tests := []struct {
+		name     string
+		spelling string
+		expected token.TokenType
+	}{
+		{"bengali", "ধরি", token.LET},
+		{"অসমীয়া", "ধৰা", token.LET},
+		{"as", "সঁচা", token.TRUE},
+		// the packs start from the bengali pack
+		{"assamese", "dhori", token.LET},
+		{"as", "শেষ", token.END},
+		{"assamese", "নাম", token.IDENT},
+		{"ককবরক", "সামুং", token.FUNC},
+		{"kokborok", "কাহাময়া", token.FALSE},
+		{"trp", "শেষ", token.END},
+		{"trp", "তেই", token.AND},
+		{"kokborok", "dhori", token.LET},
+	}
+
+	for i, tt := range tests {
+		p, ok := Get(tt.name)
+		if !ok {
+			t.Fatalf("tests[%d] -> pack %s not found", i, tt.name)
+		}
+
+		if got := p.LookupIdent(token.Normalize(tt.spelling)); got != tt.expected {
+			t.Errorf("tests[%d] -> expected %s, got %s", i, tt.expected, got)
+		}
+	}
+
+	as, _ := Get("assamese")
+	if as.HumanFriendly[token.LET] != "ধৰা" {
+		t.Errorf("expected the first spelling to name LET, got %q", as.HumanFriendly[token.LET])
+	}
+
+	if as.Errors["DELETE_FAILED"] != Default.Errors["DELETE_FAILED"] {
+		t.Errorf("expected the missing messages to come from the base pack")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"aliases = x", "no name"},
+		{"name = x\n[keywords]\nLOOP = ঘোরো", "line 3: `LOOP` is not a keyword"},
+		{"name = x\n[types]\nTUPLE = জোড়", "line 3: `TUPLE` is not a type"},
+		{"name = x\n[errors]\nOOPS = ভুল", "line 3: there is no error message called `OOPS`"},
+		{"name = x\n[errors]\nEXPECTED_GOT = `%s` নাই", "line 3: the message `EXPECTED_GOT` must have the verbs [%s %s], like the Bengali message, not [%s]"},
+		{"name = x\n[errors]\nEXPECTED_GOT = `%d` বা `%s`", "not [%d %s]"},
+		{"name = x\nbase = latin", "line 2: unknown base pack `latin`"},
+		{"name = x\n[words]", "line 2: unknown section [words]"},
+		{"name = x\nLET ধরি", "line 2: expected `name = value`"},
+	}
+
+	for i, tt := range tests {
+		_, err := Parse(tt.input)
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("tests[%d] -> expected error %q, got %v", i, tt.expected, err)
+		}
+	}
+}
+
+func TestParseErrorVerbs(t *testing.T) {
+	p, err := Parse("name = x\n[errors]\nEXPECTED_GOT = 100%% নিশ্চিত: `%s` লাগে, `%s` নয়")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	if p.Errors["EXPECTED_GOT"] != "100%% নিশ্চিত: `%s` লাগে, `%s` নয়" {
+		t.Errorf("got %q", p.Errors["EXPECTED_GOT"])
+	}
+}
+
+func TestDirective(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		{"# ভাষা: অসমীয়া\nধৰা ক = ১", "অসমীয়া", true},
+		{"#pack:assamese", "assamese", true},
+		{"# ভাষা: ককবরক", "ককবরক", true},
+		{"  # pack : assamese ", "assamese", true},
+		{"## pack: assamese", "", false},
+		{"# a comment\n# pack: assamese", "", false},
+		{"dhori x = 1", "", false},
+	}
+
+	for i, tt := range tests {
+		name, offset, ok := Directive(tt.input)
+		if name != tt.expected || ok != tt.ok {
+			t.Errorf("tests[%d] -> expected %q %t, got %q %t", i, tt.expected, tt.ok, name, ok)
+		}
+
+		if ok && tt.input[offset:offset+len(name)] != name {
+			t.Errorf("tests[%d] -> wrong offset %d", i, offset)
+		}
+	}
+}
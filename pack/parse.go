@@ -0,0 +1,180 @@
+package pack
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.cs.palashbauri.in/pankti/token"
+)
+
+// Parse reads a pack from the text of a pack file
+func Parse(data string) (*Pack, error) {
+	p := &Pack{
+		Keywords:      map[string]token.TokenType{},
+		HumanFriendly: map[string]string{},
+		TypeNames:     map[string]string{},
+		Errors:        map[string]string{},
+	}
+
+	// the first spelling of each keyword in the file, which is used to
+	// name it in error messages unless the file gives another name
+	first := map[string]string{}
+	named := map[string]bool{}
+	section := ""
+
+	for i, line := range strings.Split(data, "\n") {
+		lineNo := i + 1
+		line = strings.TrimSpace(line)
+
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if section != "keywords" && section != "tokens" && section != "types" && section != "errors" {
+				return nil, fmt.Errorf("line %d: unknown section [%s]", lineNo, section)
+			}
+
+			continue
+		}
+
+		eq := strings.Index(line, "=")
+		if eq < 0 {
+			return nil, fmt.Errorf("line %d: expected `name = value`", lineNo)
+		}
+
+		name := strings.TrimSpace(line[:eq])
+		value := strings.TrimSpace(line[eq+1:])
+
+		switch section {
+		case "":
+			if err := p.setField(name, value); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+		case "keywords":
+			if !isKeyword(name) {
+				return nil, fmt.Errorf("line %d: `%s` is not a keyword", lineNo, name)
+			}
+
+			for _, spelling := range split(value) {
+				p.Keywords[token.Normalize(spelling)] = token.TokenType(name)
+				if _, ok := first[name]; !ok {
+					first[name] = spelling
+				}
+			}
+		case "tokens":
+			p.HumanFriendly[name] = value
+			named[name] = true
+		case "types":
+			if _, ok := Default.TypeNames[name]; !ok {
+				return nil, fmt.Errorf("line %d: `%s` is not a type", lineNo, name)
+			}
+
+			p.TypeNames[name] = value
+		case "errors":
+			bengali, ok := Default.Errors[name]
+			if !ok {
+				return nil, fmt.Errorf("line %d: there is no error message called `%s`", lineNo, name)
+			}
+
+			// the message is given the same values as the Bengali one
+			if want, got := verbs(bengali), verbs(value); want != got {
+				return nil, fmt.Errorf("line %d: the message `%s` must have the verbs [%s], like the Bengali message, not [%s]", lineNo, name, want, got)
+			}
+
+			p.Errors[name] = value
+		}
+	}
+
+	if len(p.Name) == 0 {
+		return nil, fmt.Errorf("the pack has no name")
+	}
+
+	for t, spelling := range first {
+		if !named[t] {
+			p.HumanFriendly[t] = spelling
+		}
+	}
+
+	return p, nil
+}
+
+// setField sets one of the fields at the top of a pack file
+func (p *Pack) setField(name, value string) error {
+	switch name {
+	case "name":
+		p.Name = value
+	case "aliases":
+		p.Aliases = split(value)
+	case "base":
+		base, ok := Get(value)
+		if !ok {
+			return fmt.Errorf("unknown base pack `%s`", value)
+		}
+		p.inherit(base)
+	default:
+		return fmt.Errorf("unknown field `%s`", name)
+	}
+
+	return nil
+}
+
+// inherit adds everything from base which p has not set yet
+func (p *Pack) inherit(base *Pack) {
+	for kw, t := range base.Keywords {
+		if _, ok := p.Keywords[kw]; !ok {
+			p.Keywords[kw] = t
+		}
+	}
+
+	fill(p.HumanFriendly, base.HumanFriendly)
+	fill(p.TypeNames, base.TypeNames)
+	fill(p.Errors, base.Errors)
+}
+
+func fill(m, from map[string]string) {
+	for k, v := range from {
+		if _, ok := m[k]; !ok {
+			m[k] = v
+		}
+	}
+}
+
+// isKeyword reports whether t is the token type of a keyword
+func isKeyword(t string) bool {
+	for _, kt := range Default.Keywords {
+		if string(kt) == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// verbPattern matches the verbs of fmt, such as %s, %d and %.2f
+var verbPattern = regexp.MustCompile(`%[-+# 0]*(\[\d+\])?(\d+|\*)?(\.(\d+|\*)?)?[a-zA-Z%]`)
+
+// verbs returns the verbs of a message in order, leaving out %%
+func verbs(msg string) string {
+	found := []string{}
+	for _, v := range verbPattern.FindAllString(msg, -1) {
+		if v != "%%" {
+			found = append(found, v)
+		}
+	}
+
+	return strings.Join(found, " ")
+}
+
+func split(value string) []string {
+	result := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,95 @@
+package pankti
+
+import (
+	"testing"
+)
+
+func TestArrayEdits(t *testing.T) {
+	tests := []engineTest{
+		{`t.pop([1, 2, 3])`, "[1, 2]"},
+		{`t.pop([1])`, "[]"},
+		{`t.pop_at([1, 2, 3], 0)`, "[2, 3]"},
+		{`t.সুচকে_মুছুন([1, 2, 3], 2)`, "[1, 2]"},
+		{`t.pop_at([1, 2, 3], 1)`, "[1, 3]"},
+		{`t.insert([1, 2, 3], [8, 9], 1)`, "[1, 8, 9, 3]"},
+		{`t.insert_as_is([1, 2], [8, 9], 0)`, "[[8, 9], 2]"},
+		{`t.concat([1], [2])`, "[1, 2]"},
+		{`t.push([1], 3)`, "[1, 3]"},
+		{`dhori x = [1, 2, 3]
+		dhori y = t.pop_at(x, 1)
+		dhori y = t.insert(x, 5, 0)
+		dhori y = t.pop(x)
+		x`, "[1, 2, 3]"},
+	}
+
+	runBoth(t, "dhori t = anoyon \"array\"\n", tests)
+
+	failBoth(t, "dhori t = anoyon \"array\"\n", []string{
+		`t.pop([])`,
+		`t.pop_at([], 0)`,
+		`t.pop_at([1, 2], -1)`,
+		`t.pop_at([1, 2], 2)`,
+		`t.pop_at([1, 2], 0.5)`,
+		`t.insert([1, 2], 5, -1)`,
+		`t.pop("ab")`,
+	})
+}
+
+func TestArrayCallbacks(t *testing.T) {
+	tests := []engineTest{
+		{`t.map([1, 2, 3], ekti kaj(x) x * x sesh)`, "[1, 4, 9]"},
+		{`t.রূপান্তর([10, 20], ekti kaj(x, i) x + i sesh)`, "[10, 21]"},
+		{`t.map(["ক্ষমা", "ab"], t.len)`, "[2, 2]"},
+		{`t.filter([1, 2, 3, 4], ekti kaj(x) x > 2 sesh)`, "[3, 4]"},
+		{`t.ছাঁকো([1, 2], ekti kaj(x) x > 5 sesh)`, "[]"},
+		{`t.reduce([1, 2, 3], ekti kaj(a, x) a + x sesh, 10)`, "16"},
+		{`t.জমাও([], ekti kaj(a, x) a + x sesh, 0)`, "0"},
+		{`t.reduce([5, 5], ekti kaj(a, x, i) a + x * i sesh, 0)`, "5"},
+		{`t.find([5, 8, 12], ekti kaj(x) x > 6 sesh)`, "8"},
+		{`t.খোঁজো([1], ekti kaj(x) x > 6 sesh)`, "null"},
+		{`t.any([1, 2], ekti kaj(x) x > 1 sesh)`, "true"},
+		{`t.কোনোটি([], ekti kaj(x) sotto sesh)`, "false"},
+		{`t.all([1, 2], ekti kaj(x) x > 1 sesh)`, "false"},
+		{`t.সবকটি([], ekti kaj(x) mittha sesh)`, "true"},
+		{`t.sort([3, 1.5, 2])`, "[1.5, 2, 3]"},
+		{`t.সাজাও(["খ", 10, "ক", 9])`, "[9, 10, ক, খ]"},
+		{`t.sort([1, 3, 2], ekti kaj(a, b) a > b sesh)`, "[3, 2, 1]"},
+		{`t.sort([1, 3, 2], ekti kaj(a, b) b - a sesh)`, "[3, 2, 1]"},
+		{`t.sort([[2, "x"], [1, "y"], [2, "a"]], ekti kaj(a, b) a[0] < b[0] sesh)`, "[[1, y], [2, x], [2, a]]"},
+	}
+
+	runBoth(t, "dhori t = anoyon \"array\"\n", tests)
+}
+
+func TestArrayCallbackErrors(t *testing.T) {
+	failBoth(t, "dhori t = anoyon \"array\"\n", []string{
+		`t.map([1], ekti kaj(x) x + "a" sesh)`,
+		`t.map([1], ekti kaj(a, b, c) a sesh)`,
+		`t.filter([1], 5)`,
+		`t.sort([1, [2]])`,
+		`t.sort([1, 2], ekti kaj(a, b) "x" sesh)`,
+		`t.reduce([1], ekti kaj(a, x) a / x sesh, "s")`,
+	})
+}
+
+func TestBengaliSort(t *testing.T) {
+	tests := []engineTest{
+		{`t.sort(["হাত", "অজগর", "ক্ষমা", "কাক", "য়াক", "কল", "ড়", "ঢাক"])`, "[অজগর, কল, কাক, ক্ষমা, ড়, ঢাক, য়াক, হাত]"},
+		{`t.sort(["১০", "৯", 8, "খ", 11.5])`, "[8, ৯, ১০, 11.5, খ]"},
+		{`t.sort(["খ", "ক", "গ"], {"উল্টো": sotto})`, "[গ, খ, ক]"},
+		{`t.sort(["আম", "ক", "অজগর"], {"key": t.len})`, "[ক, আম, অজগর]"},
+		{`t.sort([[2, "x"], [1, "y"], [2, "a"]], {"চাবি": ekti kaj(x) x[0] sesh, "reverse": sotto})`, "[[2, x], [2, a], [1, y]]"},
+		{`t.sort([3, 1, 2], {"তুলনা": ekti kaj(a, b) a > b sesh, "stable": mittha})`, "[3, 2, 1]"},
+		{`s.compare("কাক", "ক্ষমা")`, "-1"},
+		{`s.তুলনা("১০", 9)`, "1"},
+		{`s.compare("ক", "ক")`, "0"},
+	}
+
+	runBoth(t, "dhori t = anoyon \"array\"\ndhori s = anoyon \"string\"\n", tests)
+
+	failBoth(t, "dhori t = anoyon \"array\"\ndhori s = anoyon \"string\"\n", []string{
+		`t.sort([1], {"x": sotto})`,
+		`t.sort([1], {"reverse": 1})`,
+		`s.compare("ক", [1])`,
+	})
+}
@@ -0,0 +1,33 @@
+package pankti
+
+import (
+	"testing"
+)
+
+func TestBanglaModule(t *testing.T) {
+	tests := []engineTest{
+		{`b.অক্ষর("ক্ষমা করো")`, "[ক্ষ, মা, ক, রো]"},
+		{`b.aksharas("")`, "[]"},
+		{`b.যুক্তাক্ষর("বিজ্ঞান ও প্রযুক্তি")`, "[জ্ঞা, প্র, ক্তি]"},
+		{`b.is_conjunct("ক্ষ")`, "true"},
+		{`b.is_conjunct("র\u{200d}্যা")`, "true"},
+		{`b.conjuncts("র\u{200d}্যাব")`, "[র\u200d্যা]"},
+		{`b.যুক্তাক্ষর_কি("ক্")`, "false"},
+		{`b.হসন্ত_আছে("ক্")`, "true"},
+		{`b.has_hasanta("কাক")`, "false"},
+		{`b.কার("কোকিল")`, "[ো, ি]"},
+		{`b.কার_মুছুন("বাংলা ভাষা")`, "বংল ভষ"},
+		{`b.strip_vowel_signs("abc")`, "abc"},
+		{`b.লিপি("আমার সোনার বাংলা")`, "বাংলা"},
+		{`b.script("hello")`, "লাতিন"},
+		{`b.script("আমি Go লিখি")`, "মিশ্র"},
+		{`b.script("১২৩")`, "অন্য"},
+		{`b.শব্দ("আমি, তুমি; সে")`, "[আমি, তুমি, সে]"},
+		{`b.word_count("দাম ৩.৫০ টাকা")`, "3"},
+		{`b.বাক্য("আমি ভাত খাই। তুমি কি খাবে? আর কিছু")`, "[আমি ভাত খাই।, তুমি কি খাবে?, আর কিছু]"},
+		{`b.বাক্য_সংখ্যা("এক। দুই।। তিন")`, "3"},
+		{`b.sentence_count("")`, "0"},
+	}
+
+	runBoth(t, "dhori b = anoyon \"বাংলা\"\n", tests)
+}
@@ -0,0 +1,170 @@
+package pankti
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+
+	"go.cs.palashbauri.in/pankti/number"
+	"go.cs.palashbauri.in/pankti/object"
+)
+
+// ToObject converts a Go value to a Pankti object. Supported values are
+// nil, bool, integers, floats, string, error, object.Obj, and slices and
+// string keyed maps of supported values (such as []any and
+// map[string]any)
+func ToObject(v any) (object.Obj, error) {
+	switch v := v.(type) {
+	case nil:
+		return &object.Null{}, nil
+	case object.Obj:
+		return v, nil
+	case bool:
+		return &object.Boolean{Value: v}, nil
+	case string:
+		return &object.String{Value: v}, nil
+	case int:
+		return object.MakeIntNumber(int64(v)), nil
+	case int64:
+		return object.MakeIntNumber(v), nil
+	case *big.Int:
+		return &object.Number{
+			Value: number.Number{Value: &number.IntNumber{Value: *v}, IsInt: true},
+			IsInt: true,
+		}, nil
+	case float64:
+		return object.MakeFloatNumber(v), nil
+	case error:
+		return object.NewBareErr("%s", v.Error()), nil
+	case []any:
+		elms := []object.Obj{}
+		for _, item := range v {
+			obj, err := ToObject(item)
+			if err != nil {
+				return nil, err
+			}
+			elms = append(elms, obj)
+		}
+
+		return &object.Array{Elms: elms}, nil
+	case map[string]any:
+		hash := &object.Hash{Pairs: map[object.HashKey]object.HashPair{}}
+		for key, item := range v {
+			obj, err := ToObject(item)
+			if err != nil {
+				return nil, err
+			}
+
+			k := &object.String{Value: key}
+			hash.Pairs[k.HashKey()] = object.HashPair{Key: k, Value: obj}
+		}
+
+		return hash, nil
+	}
+
+	// other integer and float types, typed slices and maps
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return object.MakeIntNumber(rv.Int()), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return object.MakeIntNumber(int64(rv.Uint())), nil
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		// values over the largest int64 stay exact as big integers
+		if u := rv.Uint(); u > math.MaxInt64 {
+			return ToObject(new(big.Int).SetUint64(u))
+		}
+
+		return object.MakeIntNumber(int64(rv.Uint())), nil
+	case reflect.Float32:
+		return object.MakeFloatNumber(rv.Float()), nil
+	case reflect.Slice, reflect.Array:
+		items := make([]any, rv.Len())
+		for i := range items {
+			items[i] = rv.Index(i).Interface()
+		}
+
+		return ToObject(items)
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			break
+		}
+
+		items := map[string]any{}
+		iter := rv.MapRange()
+		for iter.Next() {
+			items[iter.Key().String()] = iter.Value().Interface()
+		}
+
+		return ToObject(items)
+	}
+
+	return nil, fmt.Errorf("cannot convert value of type %T to a pankti object", v)
+}
+
+// FromObject converts a Pankti object to a Go value. Integers become
+// int64 (or *big.Int if they are too big), other numbers float64,
+// strings string, booleans bool, null nil, arrays []any, hashes
+// map[string]any and errors error. The keys of hashes are written as
+// strings, so a hash with both 1 and "1" as keys can not be converted
+func FromObject(obj object.Obj) (any, error) {
+	switch obj := obj.(type) {
+	case nil, *object.Null:
+		return nil, nil
+	case *object.Boolean:
+		return obj.Value, nil
+	case *object.String:
+		return obj.Value, nil
+	case *object.Number:
+		switch n := obj.Value.Value.(type) {
+		case *number.IntNumber:
+			if n.Value.IsInt64() {
+				return n.Value.Int64(), nil
+			}
+
+			return new(big.Int).Set(&n.Value), nil
+		case *number.FloatNumber:
+			f, _ := n.Value.Float64()
+			return f, nil
+		}
+	case *object.Error:
+		return errors.New(obj.Msg), nil
+	case *object.Array:
+		items := []any{}
+		for _, elm := range obj.Elms {
+			item, err := FromObject(elm)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+
+		return items, nil
+	case *object.Hash:
+		items := map[string]any{}
+		for _, pair := range obj.Pairs {
+			key, err := FromObject(pair.Key)
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := FromObject(pair.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			k := fmt.Sprint(key)
+			if _, ok := items[k]; ok {
+				return nil, fmt.Errorf("hash key %q is used twice, since keys such as 1 and \"1\" both become \"1\"", k)
+			}
+
+			items[k] = value
+		}
+
+		return items, nil
+	}
+
+	return nil, fmt.Errorf("cannot convert pankti object of type %s to a go value", obj.Type())
+}
@@ -0,0 +1,47 @@
+package pankti
+
+import (
+	"testing"
+
+	"go.cs.palashbauri.in/pankti/vfs"
+)
+
+func TestCSVModule(t *testing.T) {
+	tests := []engineTest{
+		{`c.read("a,b\n১,2\n")`, "[[a, b], [১, 2]]"},
+		{`c.পড়ো("a,b\n১,2\n", {"সংখ্যা": সত্য})[1][0] + 1`, "2"},
+		{`c.read("নাম,নম্বর\nরহিম,৮৫.৫\n", {"header": সত্য, "numbers": সত্য})[0]["নম্বর"]`, "85.5"},
+		{`c.read("x\n007\n-3\n1e2\n12a\n", {"numbers": সত্য})`, "[[x], [7], [-3], [100], [12a]]"},
+		{`c.read("a;\"b;c\"", {"delimiter": ";"})[0][1]`, "b;c"},
+		{`c.read("a,b\"c", {"lazy_quotes": সত্য})[0][1]`, "b\"c"},
+		{`c.read("", {"header": সত্য})`, "[]"},
+		{`c.লেখো([[1, "a,b"], [সত্য, "x\"y"]])`, "1,\"a,b\"\ntrue,\"x\"\"y\"\n"},
+		{`c.write([["a", "b"]], {"বিভাজক": "\t", "সব_উদ্ধৃতি": সত্য})`, "\"a\"\t\"b\"\n"},
+		{`c.write([{"খ": 2, "ক": 1}, {"ক": 3}])`, "ক,খ\n1,2\n3,\n"},
+		{`c.write([{"খ": 2, "ক": 1}], {"কলাম": ["খ", "ক"]})`, "খ,ক\n2,1\n"},
+		{`c.ফাইল_লেখো("ফল.csv", [{"নাম": "রহিম", "নম্বর": 85}])
+		c.read_file("ফল.csv", {"header": সত্য, "numbers": সত্য})[0]["নম্বর"] * 2`, "170"},
+		{`c.ফাইল_পড়ো("নম্বর.csv", {"শিরোনাম": সত্য})[1]["নাম"]`, "করিম"},
+	}
+
+	// a new filesystem for every test, so writes do not leak into others
+	fsys := func(it *Interpreter) {
+		WithFS(vfs.NewMemFrom(map[string]string{
+			"নম্বর.csv": "নাম,নম্বর\nরহিম,৮৫\nকরিম,৯০\n",
+		}))(it)
+	}
+
+	runBoth(t, "dhori c = anoyon \"সিএসভি\"\n", tests, fsys)
+}
+
+func TestCSVErrors(t *testing.T) {
+	failBoth(t, "dhori c = anoyon \"csv\"\n", []string{
+		`c.read("a,b\n1")`,
+		`c.read("a", {"delimiter": ";;"})`,
+		`c.read("a", {"separator": ","})`,
+		`c.write([[1], {"a": 1}])`,
+		`c.write([[[1]]])`,
+		`c.write([1])`,
+		`c.read_file("নেই.csv")`,
+	}, WithFS(vfs.NewMem()))
+}
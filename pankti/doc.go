@@ -0,0 +1,128 @@
+package pankti
+
+import (
+	"strings"
+
+	"go.cs.palashbauri.in/pankti/ast"
+	"go.cs.palashbauri.in/pankti/lexer"
+	"go.cs.palashbauri.in/pankti/parser"
+	"go.cs.palashbauri.in/pankti/token"
+)
+
+// Doc is the documentation of a value defined with `dhori`, written as
+// `##` lines before the definition
+type Doc struct {
+	Name string
+	// the parameters if the value is a function, otherwise nil
+	Params []string
+	Text   string
+	// where the name is written in the definition
+	Span token.Span
+}
+
+// Signature returns the name, followed by the parameters for functions
+func (d Doc) Signature() string {
+	if d.Params == nil {
+		return d.Name
+	}
+
+	return d.Name + "(" + strings.Join(d.Params, ", ") + ")"
+}
+
+// Docs returns the documented definitions of src, including the ones
+// inside of functions and blocks, in the order they are written
+func Docs(src string) ([]Doc, error) {
+	l := lexer.NewLexer(src)
+	p := parser.NewParser(&l)
+	prog := p.ParseProg()
+
+	if len(p.GetErrors()) >= 1 {
+		return nil, &ParseError{Errs: p.GetErrors()}
+	}
+
+	return collectDocs(prog.Stmts, nil), nil
+}
+
+// DocAt returns the documentation of the name at a line and column (in
+// runes, starting from 1) of src, such as for an editor to show when the
+// name is hovered. The nearest documented definition before the name is
+// used, or else the first one after it. Syntax errors elsewhere in src
+// are ignored
+func DocAt(src string, line, col int) (Doc, bool) {
+	l := lexer.NewLexer(src)
+	name, found := token.Token{}, false
+
+	for tk := l.NextToken(); tk.Type != token.EOF; tk = l.NextToken() {
+		sp := tk.Span
+		if tk.Type == token.IDENT && sp.Start.Line == line &&
+			sp.Start.Column <= col && col < sp.End.Column {
+			name, found = tk, true
+			break
+		}
+	}
+
+	if !found {
+		return Doc{}, false
+	}
+
+	l = lexer.NewLexer(src)
+	prog := parser.NewParser(&l).ParseProg()
+
+	var doc Doc
+	found = false
+	for _, d := range collectDocs(prog.Stmts, nil) {
+		if d.Name != name.Literal {
+			continue
+		}
+
+		if d.Span.Start.Offset <= name.Span.Start.Offset || !found {
+			doc, found = d, true
+		}
+	}
+
+	return doc, found
+}
+
+func collectDocs(stmts []ast.Stmt, docs []Doc) []Doc {
+	for _, stmt := range stmts {
+		switch s := stmt.(type) {
+		case *ast.LetStmt:
+			if s == nil {
+				continue
+			}
+
+			fl, isFunc := s.Value.(*ast.FunctionLit)
+			if len(s.Doc) > 0 {
+				d := Doc{Name: s.Name.Value, Text: s.Doc, Span: s.Name.Span()}
+				if isFunc {
+					d.Params = fl.ParamNames()
+				}
+				docs = append(docs, d)
+			}
+
+			if isFunc && fl.Body != nil {
+				docs = collectDocs(fl.Body.Stmts, docs)
+			}
+		case *ast.ExprStmt:
+			if s == nil {
+				continue
+			}
+
+			switch e := s.Expr.(type) {
+			case *ast.IfExpr:
+				if e.TrueBlock != nil {
+					docs = collectDocs(e.TrueBlock.Stmts, docs)
+				}
+				if e.ElseBlock != nil {
+					docs = collectDocs(e.ElseBlock.Stmts, docs)
+				}
+			case *ast.WhileExpr:
+				if e.StmtBlock != nil {
+					docs = collectDocs(e.StmtBlock.Stmts, docs)
+				}
+			}
+		}
+	}
+
+	return docs
+}
@@ -0,0 +1,50 @@
+package pankti
+
+import (
+	"testing"
+)
+
+const docSrc = `## দুটি সংখ্যা যোগ করে
+dhori যোগ = ekti kaj(ক, খ)
+	ফেরাও ক + খ
+sesh
+
+## একটি নাম
+dhori নাম = "পলাশ"
+যোগ(নাম, "!")`
+
+func TestHelp(t *testing.T) {
+	tests := []engineTest{
+		{docSrc + "\nhelp(যোগ)", "যোগ(ক, খ)\nদুটি সংখ্যা যোগ করে"},
+		{`dhori f = ekti kaj() sesh
+		সাহায্য(f)`, "f()"},
+		{`help(len)`, "len(মান: তালিকা/স্ট্রিং)"},
+	}
+
+	runBoth(t, "", tests)
+}
+
+func TestDocs(t *testing.T) {
+	docs, err := Docs(docSrc)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	if len(docs) != 2 || docs[0].Signature() != "যোগ(ক, খ)" || docs[1].Signature() != "নাম" {
+		t.Fatalf("wrong docs %v", docs)
+	}
+
+	if docs[0].Span.Start.Line != 2 || docs[0].Span.Start.Column != 7 {
+		t.Errorf("wrong span %+v", docs[0].Span)
+	}
+
+	// the call to যোগ on the last line
+	d, ok := DocAt(docSrc, 8, 2)
+	if !ok || d.Text != "দুটি সংখ্যা যোগ করে" {
+		t.Errorf("wrong doc at 8:2 -> %v, %v", d, ok)
+	}
+
+	if _, ok := DocAt(docSrc, 8, 4); ok {
+		t.Errorf("expected no doc at 8:4")
+	}
+}
@@ -0,0 +1,64 @@
+package pankti
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"go.cs.palashbauri.in/pankti/object"
+	"go.cs.palashbauri.in/pankti/vfs"
+)
+
+func TestMemFS(t *testing.T) {
+	tests := []engineTest{
+		{`dhori f = anoyon "file"
+		f.পড়ো("ক.txt")`, "ক"},
+		{`dhori f = anoyon "file"
+		f.লেখো("খ.txt", "খ")
+		f.লাইন_যোগ("খ.txt", "গ")
+		f.পড়ো("/খ.txt")`, "খগ"},
+		{`dhori f = anoyon "file"
+		f.নাম_পরিবর্তন("ক.txt", "ঘ.txt")
+		f.আছে_কি("ক.txt")`, "false"},
+		{`dhori f = anoyon "file"
+		f.মুছুন("ক.txt")
+		f.ফাইলের_তালিকা("/")`, "[/, /মডিউল.pank]"},
+		{`dhori m = anoyon "মডিউল.pank"
+		m.নাম`, "মডিউল"},
+	}
+
+	// a new filesystem for every test, so changes do not leak into others
+	fsys := func(it *Interpreter) {
+		WithFS(vfs.NewMemFrom(map[string]string{
+			"ক.txt":      "ক",
+			"মডিউল.pank": `dhori নাম = "মডিউল"`,
+		}))(it)
+	}
+
+	runBoth(t, "", tests, fsys)
+}
+
+func TestMemFSInsideRoot(t *testing.T) {
+	fsys := vfs.NewMemFrom(map[string]string{
+		"/ঘর/ক.txt": "ক",
+		"/খ.txt":    "খ",
+	})
+
+	it := New(
+		WithFS(fsys),
+		WithPermissions(object.Permissions{FSRoot: "ঘর"}),
+		WithStderr(io.Discard),
+	)
+
+	res, err := it.Run(context.Background(), `dhori f = anoyon "file"
+	f.পড়ো("ক.txt")`)
+	if err != nil || res.Inspect() != "ক" {
+		t.Errorf("expected \"ক\", got %v, %v", res, err)
+	}
+
+	_, err = it.Run(context.Background(), `dhori f = anoyon "file"
+	f.পড়ো("/খ.txt")`)
+	if err == nil || !hasErrMsg(err, "PERM_OUTSIDE_ROOT") {
+		t.Errorf("expected PERM_OUTSIDE_ROOT error, got %v", err)
+	}
+}
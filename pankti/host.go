@@ -0,0 +1,313 @@
+package pankti
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"go.cs.palashbauri.in/pankti/object"
+	"go.cs.palashbauri.in/pankti/token"
+)
+
+var (
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+	objType   = reflect.TypeOf((*object.Obj)(nil)).Elem()
+)
+
+// Register makes a Go function available to Pankti programs as a global
+// function called name. The parameters and results of fn are converted
+// with FromObject and ToObject; fn can return nothing, a value, an
+// error, or a value and an error. A non nil error stops the program.
+//
+//	it.Register("খোঁজো", func(id int64) (string, error) { ... })
+func (it *Interpreter) Register(name string, fn any) error {
+	b, err := hostFunc(name, fn)
+	if err != nil {
+		return err
+	}
+
+	it.define(name, b)
+	return nil
+}
+
+// SetGlobal makes a Go value available to Pankti programs as a global
+// variable called name
+func (it *Interpreter) SetGlobal(name string, v any) error {
+	obj, err := ToObject(v)
+	if err != nil {
+		return err
+	}
+
+	it.define(name, obj)
+	return nil
+}
+
+// RegisterModule defines a module which Pankti programs can include with
+// `anoyon "name"`. Functions among the members are wrapped like Register
+// and other values are converted like SetGlobal
+func (it *Interpreter) RegisterModule(name string, members map[string]any) error {
+	objs := map[string]object.Obj{}
+
+	for mname, member := range members {
+		var obj object.Obj
+		var err error
+
+		if reflect.TypeOf(member) != nil && reflect.TypeOf(member).Kind() == reflect.Func {
+			obj, err = hostFunc(mname, member)
+		} else {
+			obj, err = ToObject(member)
+		}
+
+		if err != nil {
+			return fmt.Errorf("module %s: %w", name, err)
+		}
+
+		objs[token.Normalize(mname)] = obj
+	}
+
+	if it.rt.HostModules == nil {
+		it.rt.HostModules = map[string]map[string]object.Obj{}
+	}
+
+	it.rt.HostModules[name] = objs
+	return nil
+}
+
+// define sets a global variable for both of the engines
+func (it *Interpreter) define(name string, obj object.Obj) {
+	name = token.Normalize(name)
+	it.env.SetToDefault(name, obj)
+
+	sm := it.symTable.Define(name)
+	it.globals[sm.Index] = obj
+}
+
+// hostFunc wraps a Go function as a builtin function
+func hostFunc(name string, fn any) (*object.Builtin, error) {
+	fv := reflect.ValueOf(fn)
+	if fv.Kind() != reflect.Func {
+		return nil, fmt.Errorf("%s: %T is not a function", name, fn)
+	}
+
+	if fv.IsNil() {
+		return nil, fmt.Errorf("%s: the function is nil", name)
+	}
+
+	ft := fv.Type()
+
+	switch {
+	case ft.NumOut() > 2,
+		ft.NumOut() == 2 && ft.Out(1) != errorType:
+		return nil, fmt.Errorf("%s: functions can only return a value and an error", name)
+	}
+
+	sig := &object.Signature{Name: token.Normalize(name), Variadic: ft.IsVariadic()}
+	for i := 0; i < ft.NumIn(); i++ {
+		t := ft.In(i)
+		if ft.IsVariadic() && i == ft.NumIn()-1 {
+			t = t.Elem()
+		}
+
+		sig.Params = append(sig.Params, object.Param{
+			Name:  fmt.Sprintf("মান_%d", i+1),
+			Types: objTypesOf(t),
+		})
+	}
+
+	return &object.Builtin{
+		Sig: sig,
+		Fn: func(eh *object.ErrorHelper, env *object.EnvMap, caller token.Token, args ...object.Obj) object.Obj {
+			in := []reflect.Value{}
+
+			for i, arg := range args {
+				var t reflect.Type
+				if ft.IsVariadic() && i >= ft.NumIn()-1 {
+					t = ft.In(ft.NumIn() - 1).Elem()
+				} else {
+					t = ft.In(i)
+				}
+
+				v, err := toGoValue(arg, t)
+				if err != nil {
+					return object.NewErr(caller, eh, false, eh.Msg("HOST_VALUE_CONVERT"), name, err.Error())
+				}
+
+				in = append(in, v)
+			}
+
+			out := fv.Call(in)
+
+			if len(out) > 0 && ft.Out(len(out)-1) == errorType {
+				if err, _ := out[len(out)-1].Interface().(error); err != nil {
+					return object.NewErr(caller, eh, false, eh.Msg("HOST_FUNC_FAILED"), name, err.Error())
+				}
+				out = out[:len(out)-1]
+			}
+
+			if len(out) == 0 {
+				return &object.Null{}
+			}
+
+			result, err := ToObject(out[0].Interface())
+			if err != nil {
+				return object.NewErr(caller, eh, false, eh.Msg("HOST_VALUE_CONVERT"), name, err.Error())
+			}
+
+			return result
+		},
+	}, nil
+}
+
+// toGoValue converts an argument to the type of a parameter
+func toGoValue(arg object.Obj, t reflect.Type) (reflect.Value, error) {
+	if t == objType {
+		return reflect.ValueOf(&arg).Elem(), nil
+	}
+
+	v, err := FromObject(arg)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	return convertValue(v, t)
+}
+
+func convertValue(v any, t reflect.Type) (reflect.Value, error) {
+	if v == nil {
+		return reflect.Zero(t), nil
+	}
+
+	rv := reflect.ValueOf(v)
+
+	if rv.Type().AssignableTo(t) {
+		v := reflect.New(t).Elem()
+		v.Set(rv)
+		return v, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		switch rv.Kind() {
+		case reflect.Int64, reflect.Float64:
+			return convertNumber(rv, t)
+		}
+	case reflect.Slice:
+		if items, ok := v.([]any); ok {
+			slice := reflect.MakeSlice(t, len(items), len(items))
+			for i, item := range items {
+				elm, err := convertValue(item, t.Elem())
+				if err != nil {
+					return reflect.Value{}, err
+				}
+				slice.Index(i).Set(elm)
+			}
+
+			return slice, nil
+		}
+	case reflect.Map:
+		if items, ok := v.(map[string]any); ok && t.Key().Kind() == reflect.String {
+			m := reflect.MakeMapWithSize(t, len(items))
+			for key, item := range items {
+				elm, err := convertValue(item, t.Elem())
+				if err != nil {
+					return reflect.Value{}, err
+				}
+				m.SetMapIndex(reflect.ValueOf(key).Convert(t.Key()), elm)
+			}
+
+			return m, nil
+		}
+	}
+
+	return reflect.Value{}, fmt.Errorf("cannot use %T as %s", v, t)
+}
+
+// convertNumber converts an int64 or a float64 to the number type t. A
+// float is only converted to an integer type if it is a whole number, and
+// the value must fit in t
+func convertNumber(rv reflect.Value, t reflect.Type) (reflect.Value, error) {
+	out := reflect.New(t).Elem()
+
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f := rv.Convert(reflect.TypeOf(float64(0))).Float()
+		if out.OverflowFloat(f) {
+			return reflect.Value{}, fmt.Errorf("%v is out of the range of %s", rv, t)
+		}
+		out.SetFloat(f)
+		return out, nil
+	}
+
+	if rv.Kind() == reflect.Float64 {
+		f := rv.Float()
+		if f != math.Trunc(f) {
+			return reflect.Value{}, fmt.Errorf("cannot use %v as %s, it is not a whole number", f, t)
+		}
+
+		// the range of int64 and of uint64 together
+		if f < math.MinInt64 || f >= math.MaxUint64 {
+			return reflect.Value{}, fmt.Errorf("%v is out of the range of %s", f, t)
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u uint64
+		if rv.Kind() == reflect.Float64 {
+			if rv.Float() < 0 {
+				return reflect.Value{}, fmt.Errorf("%v is out of the range of %s", rv, t)
+			}
+			u = uint64(rv.Float())
+		} else {
+			if rv.Int() < 0 {
+				return reflect.Value{}, fmt.Errorf("%v is out of the range of %s", rv, t)
+			}
+			u = uint64(rv.Int())
+		}
+
+		if out.OverflowUint(u) {
+			return reflect.Value{}, fmt.Errorf("%v is out of the range of %s", rv, t)
+		}
+		out.SetUint(u)
+	default:
+		var i int64
+		if rv.Kind() == reflect.Float64 {
+			if rv.Float() >= math.MaxInt64 {
+				return reflect.Value{}, fmt.Errorf("%v is out of the range of %s", rv, t)
+			}
+			i = int64(rv.Float())
+		} else {
+			i = rv.Int()
+		}
+
+		if out.OverflowInt(i) {
+			return reflect.Value{}, fmt.Errorf("%v is out of the range of %s", rv, t)
+		}
+		out.SetInt(i)
+	}
+
+	return out, nil
+}
+
+// objTypesOf returns the object types which can be converted to a go type;
+// nil means any type
+func objTypesOf(t reflect.Type) []object.ObjType {
+	switch t.Kind() {
+	case reflect.Bool:
+		return []object.ObjType{object.BOOL_OBJ}
+	case reflect.String:
+		return []object.ObjType{object.STRING_OBJ}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return []object.ObjType{object.NUM_OBJ}
+	case reflect.Slice:
+		return []object.ObjType{object.ARRAY_OBJ}
+	case reflect.Map:
+		return []object.ObjType{object.HASH_OBJ}
+	}
+
+	return nil
+}
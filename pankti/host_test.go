@@ -0,0 +1,181 @@
+package pankti
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	for _, engine := range []Engine{Evaluator, VM} {
+		out := bytes.Buffer{}
+		it := New(WithStdout(&out), WithStderr(&out), WithEngine(engine))
+
+		err := it.Register("greet", func(name string, times int) string {
+			return strings.Repeat(name, times)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		it.Register("sum", func(nums ...float64) float64 {
+			total := 0.0
+			for _, n := range nums {
+				total += n
+			}
+			return total
+		})
+		it.Register("fail", func() error { return errors.New("host failure") })
+		it.SetGlobal("config", map[string]any{"name": "pankti", "tags": []any{"a", "b"}})
+		it.RegisterModule("host", map[string]any{
+			"upper":   strings.ToUpper,
+			"version": "1.0",
+		})
+
+		res, err := it.Eval(context.Background(), `greet("ab", 2)`)
+		if err != nil {
+			t.Fatalf("engine %d -> %s", engine, err)
+		}
+
+		if got, _ := FromObject(res); got != "abab" {
+			t.Errorf("engine %d -> wrong result %v", engine, got)
+		}
+
+		res, _ = it.Eval(context.Background(), `sum(1, 2.5, 3)`)
+		if got, _ := FromObject(res); got != 6.5 {
+			t.Errorf("engine %d -> wrong variadic result %v", engine, got)
+		}
+
+		res, _ = it.Eval(context.Background(), `config["tags"]`)
+		if got, _ := FromObject(res); !reflect.DeepEqual(got, []any{"a", "b"}) {
+			t.Errorf("engine %d -> wrong global %v", engine, got)
+		}
+
+		res, err = it.Run(context.Background(), `dhori h = anoyon "host"
+		h.upper(h.version + "x")`)
+		if got, _ := FromObject(res); err != nil || got != "1.0X" {
+			t.Errorf("engine %d -> wrong module result %v (%v)", engine, got, err)
+		}
+
+		if _, err := it.Eval(context.Background(), `fail()`); err == nil ||
+			!strings.Contains(err.Error(), "host failure") {
+			t.Errorf("engine %d -> host error not reported; got %v", engine, err)
+		}
+
+		if _, err := it.Eval(context.Background(), `greet(1, 2)`); err == nil {
+			t.Errorf("engine %d -> expected argument type error", engine)
+		}
+	}
+}
+
+func TestRegisterNumbers(t *testing.T) {
+	tests := []struct {
+		src      string
+		expected string
+	}{
+		{`whole(3)`, "3"},
+		{`whole(3.0)`, "3"},
+		{`whole(1.9)`, ""},
+		{`small(127)`, "127"},
+		{`small(300)`, ""},
+		{`small(-1.5)`, ""},
+		{`count(7)`, "7"},
+		{`count(-1)`, ""},
+		{`real(1.5)`, "1.5"},
+	}
+
+	for _, engine := range []Engine{Evaluator, VM} {
+		it := New(WithStderr(io.Discard), WithEngine(engine))
+		it.Register("whole", func(n int) int { return n })
+		it.Register("small", func(n int8) int8 { return n })
+		it.Register("count", func(n uint) uint { return n })
+		it.Register("real", func(n float32) float32 { return n })
+
+		for i, tt := range tests {
+			res, err := it.Eval(context.Background(), tt.src)
+			if tt.expected == "" {
+				if err == nil {
+					t.Errorf("engine %d, tests[%d] -> expected an argument error, got %s", engine, i, res.Inspect())
+				}
+				continue
+			}
+
+			if err != nil {
+				t.Errorf("engine %d, tests[%d] -> unexpected error %s", engine, i, err)
+				continue
+			}
+
+			if res.Inspect() != tt.expected {
+				t.Errorf("engine %d, tests[%d] -> expected %s, got %s", engine, i, tt.expected, res.Inspect())
+			}
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	values := []any{
+		nil,
+		true,
+		int64(42),
+		3.5,
+		"লেখা",
+		[]any{int64(1), "x", []any{false}},
+		map[string]any{"a": int64(1), "b": map[string]any{"c": "d"}},
+	}
+
+	for _, v := range values {
+		obj, err := ToObject(v)
+		if err != nil {
+			t.Fatalf("cannot convert %v: %s", v, err)
+		}
+
+		back, err := FromObject(obj)
+		if err != nil {
+			t.Fatalf("cannot convert back %v: %s", v, err)
+		}
+
+		if !reflect.DeepEqual(v, back) {
+			t.Errorf("wrong round trip; wanted %#v got %#v", v, back)
+		}
+	}
+
+	if _, err := ToObject(struct{}{}); err == nil {
+		t.Errorf("expected error for converting a struct")
+	}
+
+	for v, expected := range map[any]string{
+		uint64(math.MaxUint64): "18446744073709551615",
+		uint(7):                "7",
+		uintptr(8):             "8",
+	} {
+		if obj, err := ToObject(v); err != nil || obj.Inspect() != expected {
+			t.Errorf("expected %s for %T, got %v, %v", expected, v, obj, err)
+		}
+	}
+
+	it := New(WithStderr(io.Discard))
+	res, err := it.Eval(context.Background(), `{1: "a", "1": "b"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := FromObject(res); err == nil {
+		t.Errorf("expected error for the keys 1 and \"1\" of a hash")
+	}
+}
+
+func TestRegisterNotFunction(t *testing.T) {
+	var nilFn func() int
+
+	for i, fn := range []any{nil, nilFn, 5} {
+		it := New()
+		if err := it.Register("x", fn); err == nil {
+			t.Errorf("tests[%d] -> expected error for registering %T", i, fn)
+		}
+	}
+}
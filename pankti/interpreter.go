@@ -0,0 +1,293 @@
+// Package pankti runs Pankti programs from Go.
+//
+//	it := pankti.New(pankti.WithStdout(&out))
+//	result, err := it.Run(context.Background(), src)
+package pankti
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.cs.palashbauri.in/pankti/ast"
+	"go.cs.palashbauri.in/pankti/compiler"
+	"go.cs.palashbauri.in/pankti/errs"
+	"go.cs.palashbauri.in/pankti/evaluator"
+	"go.cs.palashbauri.in/pankti/lexer"
+	"go.cs.palashbauri.in/pankti/number"
+	"go.cs.palashbauri.in/pankti/object"
+	"go.cs.palashbauri.in/pankti/pack"
+	"go.cs.palashbauri.in/pankti/parser"
+	"go.cs.palashbauri.in/pankti/vfs"
+	"go.cs.palashbauri.in/pankti/vm"
+)
+
+// Engine selects how programs are executed
+type Engine int
+
+const (
+	// Evaluator walks the syntax tree directly; it supports the whole
+	// language and is the default
+	Evaluator Engine = iota
+	// VM compiles programs to bytecode and runs them on the virtual
+	// machine; it does not support every construct yet
+	VM
+)
+
+// Option configures an Interpreter
+type Option func(*Interpreter)
+
+// WithStdout sets where the output of `dekhau` goes
+func WithStdout(w io.Writer) Option {
+	return func(it *Interpreter) { it.rt.Stdout = w }
+}
+
+// WithStderr sets where errors are reported
+func WithStderr(w io.Writer) Option {
+	return func(it *Interpreter) { it.rt.Stderr = w }
+}
+
+// WithStdin sets where programs read their input from
+func WithStdin(r io.Reader) Option {
+	return func(it *Interpreter) { it.rt.Stdin = r }
+}
+
+// WithInput sets a function which asks the user for a line of input
+// whenever a program reads one; it is used instead of the stdin
+func WithInput(fn object.LineReader) Option {
+	return func(it *Interpreter) { it.rt.Input = fn }
+}
+
+// WithInputLines gives the lines programs read as their input, for
+// example in tests or when the input is known before the program runs
+func WithInputLines(lines ...string) Option {
+	return WithStdin(strings.NewReader(strings.Join(lines, "\n")))
+}
+
+// WithEngine sets the engine used to run programs
+func WithEngine(e Engine) Option {
+	return func(it *Interpreter) { it.engine = e }
+}
+
+// WithModuleLoader sets how `anoyon` finds modules which are not part
+// of the standard library. By default they are read from files
+func WithModuleLoader(l object.ModuleLoader) Option {
+	return func(it *Interpreter) { it.rt.Loader = l }
+}
+
+// WithLimits restricts how much a program can do; see object.Limits.
+// A timeout can be set with the context passed to Run and Eval
+func WithLimits(l object.Limits) Option {
+	return func(it *Interpreter) { it.rt.Limits = l }
+}
+
+// WithFS sets the filesystem used by the file module and to load
+// modules, instead of the one of the operating system
+func WithFS(fsys vfs.FS) Option {
+	return func(it *Interpreter) { it.rt.FS = fsys }
+}
+
+// WithPermissions restricts what programs can do with the files, the
+// operating system and the stdin; see object.Permissions
+func WithPermissions(p object.Permissions) Option {
+	return func(it *Interpreter) { it.rt.Perms = p }
+}
+
+// WithColor colors error messages with ANSI escape codes, for a terminal
+func WithColor(color bool) Option {
+	return func(it *Interpreter) { it.color = color }
+}
+
+// WithDigits sets the digits numbers are shown with, by dekhau, when
+// converted to strings and in error messages
+func WithDigits(d number.Digits) Option {
+	return func(it *Interpreter) { it.rt.Digits = d }
+}
+
+// WithPack sets the keyword pack of the programs, which also gives the
+// error messages and the names of types. A program can still choose
+// another pack with the comment on its first line
+func WithPack(p *pack.Pack) Option {
+	return func(it *Interpreter) { it.pack = p }
+}
+
+// Interpreter runs Pankti programs. Variables, functions and included
+// modules are kept between calls to Run and Eval, so an Interpreter can
+// be used for a REPL. It must not be used from multiple goroutines at
+// the same time
+type Interpreter struct {
+	engine Engine
+	rt     *object.Runtime
+	color  bool
+	pack   *pack.Pack
+
+	// state of the evaluator
+	env *object.EnvMap
+
+	// state of the vm
+	symTable  *compiler.SymbolTable
+	constants []object.Obj
+	globals   []object.Obj
+}
+
+// New creates an Interpreter. Without options it uses the standard input
+// and output of the process and the evaluator engine
+func New(opts ...Option) *Interpreter {
+	def := object.DefaultRuntime()
+	it := &Interpreter{
+		engine: Evaluator,
+		rt: &object.Runtime{
+			Stdout: def.Stdout,
+			Stderr: def.Stderr,
+			Stdin:  def.Stdin,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(it)
+	}
+
+	it.env = object.NewEnvMap()
+	it.env.Runtime = it.rt
+
+	it.symTable = compiler.NewCompiler().SymbolTable()
+	it.globals = make([]object.Obj, vm.GlobalsSize)
+	return it
+}
+
+// ParseError is returned when the source code has syntax errors
+type ParseError struct {
+	Errs []errs.ParserError
+}
+
+func (e *ParseError) Error() string {
+	msgs := []string{}
+	for _, item := range e.Errs {
+		msgs = append(msgs, item.String())
+	}
+
+	return strings.Join(msgs, " \n")
+}
+
+// RuntimeError is returned when a program stops because of an error.
+// If a limit of the program stopped it, errors.As finds the
+// *object.LimitError in it
+type RuntimeError struct {
+	Msg string
+	// what caused the error, if it is not an error of the program
+	Err error
+}
+
+func (e *RuntimeError) Error() string { return e.Msg }
+func (e *RuntimeError) Unwrap() error { return e.Err }
+
+// Run runs a program and returns the value of its last statement.
+// Errors are also reported to the stderr of the interpreter
+func (it *Interpreter) Run(ctx context.Context, src string) (object.Obj, error) {
+	prog, err := it.parse(src)
+	if err != nil {
+		return nil, it.report(err)
+	}
+
+	return it.exec(ctx, prog, src)
+}
+
+// Eval evaluates a single expression and returns its value
+func (it *Interpreter) Eval(ctx context.Context, expr string) (object.Obj, error) {
+	prog, err := it.parse(expr)
+	if err != nil {
+		return nil, it.report(err)
+	}
+
+	if len(prog.Stmts) != 1 {
+		return nil, it.report(fmt.Errorf("expected a single expression, got %d statements", len(prog.Stmts)))
+	}
+
+	if _, ok := prog.Stmts[0].(*ast.ExprStmt); !ok {
+		return nil, it.report(fmt.Errorf("expected an expression, got `%s`", prog.Stmts[0].String()))
+	}
+
+	return it.exec(ctx, prog, expr)
+}
+
+func (it *Interpreter) parse(src string) (*ast.Program, error) {
+	l := lexer.NewLexerWithPack(src, it.pack)
+	p := parser.NewParser(&l)
+	p.SetColor(it.color)
+	prog := p.ParseProg()
+
+	if len(p.GetErrors()) >= 1 {
+		return nil, &ParseError{Errs: p.GetErrors()}
+	}
+
+	for _, w := range p.GetWarnings() {
+		fmt.Fprintln(it.rt.Stderr, w.String())
+	}
+
+	// the pack named by the first line of src, or else the one of the
+	// interpreter, gives the messages of the run
+	it.rt.Pack = l.Pack()
+	return prog, nil
+}
+
+func (it *Interpreter) exec(ctx context.Context, prog *ast.Program, src string) (result object.Obj, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, it.report(err)
+	}
+
+	it.rt.Ctx = ctx
+	it.rt.Reset()
+
+	// a bug in the interpreter or in a host function must not crash the
+	// host
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = it.report(&RuntimeError{Msg: fmt.Sprintf(it.rt.Pack.Msg("INTERNAL_ERROR"), r)})
+		}
+	}()
+
+	switch it.engine {
+	case VM:
+		return it.execVM(prog, src)
+	default:
+		eh := object.ErrorHelper{Source: src, Color: it.color, Digits: it.rt.Digits, Pack: it.rt.Pack}
+		result := evaluator.Eval(prog, it.env, eh)
+
+		if e, ok := result.(*object.Error); ok {
+			return nil, it.report(&RuntimeError{Msg: e.Msg, Err: e.Err})
+		}
+
+		return result, nil
+	}
+}
+
+func (it *Interpreter) execVM(prog *ast.Program, src string) (object.Obj, error) {
+	comp := compiler.NewCompilerWithState(it.symTable, it.constants)
+	comp.SetRuntime(it.rt)
+	comp.SetSource(src, it.color)
+
+	if err := comp.Compile(prog); err != nil {
+		return nil, it.report(&RuntimeError{Msg: err.Error()})
+	}
+
+	it.symTable = comp.SymbolTable()
+	it.constants = comp.ByteCode().Constants
+
+	machine := vm.NewVMWithGlobals(*comp.ByteCode(), it.globals)
+	machine.SetRuntime(it.rt)
+	machine.SetSource(src, it.color)
+
+	if err := machine.Run(); err != nil {
+		return nil, it.report(&RuntimeError{Msg: err.Error(), Err: err})
+	}
+
+	return machine.LastPoppedStackItem(), nil
+}
+
+// report writes the error to the stderr of the interpreter and returns it
+func (it *Interpreter) report(err error) error {
+	fmt.Fprintln(it.rt.Stderr, err.Error())
+	return err
+}
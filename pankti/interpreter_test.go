@@ -0,0 +1,343 @@
+package pankti
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"go.cs.palashbauri.in/pankti/object"
+)
+
+// engineTest is a program and the inspected value of its result
+type engineTest struct {
+	src      string
+	expected string
+}
+
+// runBoth runs each test after the prelude with both engines, on a new
+// interpreter made with the options
+func runBoth(t *testing.T, prelude string, tests []engineTest, opts ...Option) {
+	t.Helper()
+
+	for _, engine := range []Engine{Evaluator, VM} {
+		for i, tt := range tests {
+			it := New(append([]Option{WithEngine(engine), WithStderr(io.Discard)}, opts...)...)
+			res, err := it.Run(context.Background(), prelude+tt.src)
+			if err != nil {
+				t.Errorf("engine %d, tests[%d] -> unexpected error %s", engine, i, err)
+				continue
+			}
+
+			if res.Inspect() != tt.expected {
+				t.Errorf("engine %d, tests[%d] -> expected %q, got %q", engine, i, tt.expected, res.Inspect())
+			}
+		}
+	}
+}
+
+// failBoth is like runBoth for programs which must fail
+func failBoth(t *testing.T, prelude string, srcs []string, opts ...Option) {
+	t.Helper()
+
+	for _, engine := range []Engine{Evaluator, VM} {
+		for i, src := range srcs {
+			it := New(append([]Option{WithEngine(engine), WithStderr(io.Discard)}, opts...)...)
+			if res, err := it.Run(context.Background(), prelude+src); err == nil {
+				t.Errorf("engine %d, tests[%d] -> expected an error, got %s", engine, i, res.Inspect())
+			}
+		}
+	}
+}
+
+func TestRunOutput(t *testing.T) {
+	for _, engine := range []Engine{Evaluator, VM} {
+		out := bytes.Buffer{}
+		it := New(WithStdout(&out), WithEngine(engine))
+
+		_, err := it.Run(context.Background(), `dhori x = "নমস্কার"
+		dekhau(x, " পৃথিবী")`)
+		if err != nil {
+			t.Fatalf("engine %d -> unexpected error %s", engine, err)
+		}
+
+		if out.String() != "নমস্কার পৃথিবী\n" {
+			t.Errorf("engine %d -> wrong output %q", engine, out.String())
+		}
+
+		res, err := it.Eval(context.Background(), `x + "!"`)
+		if err != nil {
+			t.Fatalf("engine %d -> unexpected error %s", engine, err)
+		}
+
+		if s, ok := res.(*object.String); !ok || s.Value != "নমস্কার!" {
+			t.Errorf("engine %d -> state not kept between runs; got %v", engine, res)
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	errOut := bytes.Buffer{}
+	it := New(WithStderr(&errOut))
+
+	_, err := it.Run(context.Background(), `dhori = 1`)
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Errorf("expected parse error, got %v", err)
+	}
+
+	_, err = it.Run(context.Background(), `অজানা + 1`)
+	var rerr *RuntimeError
+	if !errors.As(err, &rerr) {
+		t.Errorf("expected runtime error, got %v", err)
+	}
+
+	if errOut.Len() == 0 {
+		t.Errorf("errors were not reported to stderr")
+	}
+
+	if _, err := it.Eval(context.Background(), `dhori x = 1`); err == nil {
+		t.Errorf("expected error for evaluating a statement")
+	}
+}
+
+func TestErrorPositions(t *testing.T) {
+	tests := []struct {
+		src  string
+		line string
+	}{
+		{"dhori f = ekti kaj(a, b) a sesh\n\nf(1)", "3 | f(1)"},
+		{"\n\n1 + sotto", "3 | 1 + sotto"},
+		{"\n\ny", "3 | y"},
+		{"\n\n5[0]", "3 | 5[0]"},
+		{"\n\n-\"a\"", "3 | -\"a\""},
+		{"dhori s = anoyon \"string\"\n\ns.format(\"%d\", \"x\")", "3 | s.format"},
+	}
+
+	for _, engine := range []Engine{Evaluator, VM} {
+		for i, tt := range tests {
+			it := New(WithEngine(engine), WithStderr(io.Discard))
+			_, err := it.Run(context.Background(), tt.src)
+			if err == nil || !strings.Contains(err.Error(), tt.line) {
+				t.Errorf("engine %d, tests[%d] -> expected the error at %q, got %v", engine, i, tt.line, err)
+			}
+		}
+	}
+}
+
+func TestModuleParseError(t *testing.T) {
+	loader := func(name string) (string, bool) {
+		return "dhori ক = 1\ndhori = 2", name == "ভুল"
+	}
+
+	for _, engine := range []Engine{Evaluator, VM} {
+		it := New(WithEngine(engine), WithStderr(io.Discard), WithModuleLoader(loader))
+		_, err := it.Run(context.Background(), "dhori m = anoyon \"ভুল\"\ndekhau(1)")
+		if err == nil || !strings.Contains(err.Error(), "ভুল") || !strings.Contains(err.Error(), "2 | dhori = 2") {
+			t.Errorf("engine %d -> expected the error in the module, got %v", engine, err)
+		}
+	}
+}
+
+func TestModuleRunError(t *testing.T) {
+	loader := func(name string) (string, bool) {
+		if name == "গভীর" {
+			return "dhori f = ekti kaj(n) f(n + 1) sesh\nf(0)", true
+		}
+
+		return "dhori ক = 1\n\nঅজানা", name == "ভুল"
+	}
+
+	for _, engine := range []Engine{Evaluator, VM} {
+		it := New(WithEngine(engine), WithStderr(io.Discard), WithModuleLoader(loader))
+		_, err := it.Run(context.Background(), "dhori m = anoyon \"ভুল\"\ndekhau(1)")
+		// the evaluator also shows the line of the module itself
+		if err == nil || !strings.Contains(err.Error(), "অজানা") {
+			t.Errorf("engine %d -> expected the error in the module, got %v", engine, err)
+		} else if engine == Evaluator && !strings.Contains(err.Error(), "3 | অজানা") {
+			t.Errorf("engine %d -> expected the line of the module, got %v", engine, err)
+		}
+
+		it = New(WithEngine(engine), WithStderr(io.Discard), WithModuleLoader(loader), WithLimits(object.Limits{MaxCallDepth: 50}))
+		_, err = it.Run(context.Background(), "dhori m = anoyon \"গভীর\"\ndekhau(1)")
+		var lerr *object.LimitError
+		if !errors.As(err, &lerr) {
+			t.Errorf("engine %d -> expected the limit error of the module, got %v", engine, err)
+		}
+	}
+}
+
+func TestLimits(t *testing.T) {
+	tests := []struct {
+		src    string
+		limits object.Limits
+		ctx    func() (context.Context, context.CancelFunc)
+	}{
+		{`dhori x = 0
+		jotokhon (sotto) dhori x = x + 1 sesh`, object.Limits{MaxSteps: 10000}, nil},
+		{`dhori f = ekti kaj(n) f(n + 1) sesh
+		f(0)`, object.Limits{MaxCallDepth: 100}, nil},
+		{`dhori f = ekti kaj(n) f(n + 1) sesh
+		f(0)`, object.Limits{}, nil},
+		{`dhori t = anoyon "array"
+		dhori f = ekti kaj(n) t.map([n], f) sesh
+		f(0)`, object.Limits{MaxCallDepth: 100}, nil},
+		{`dhori s = "ab"
+		jotokhon (sotto) dhori s = s + s sesh`, object.Limits{MaxAlloc: 1 << 20}, nil},
+		{`dhori f = ekti kaj(n) f(n + 1) sesh
+		f(0)`, object.Limits{MaxCallDepth: 5000}, nil},
+		{`jotokhon (sotto) 1 sesh`, object.Limits{}, func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), 50*time.Millisecond)
+		}},
+	}
+
+	for i, tt := range tests {
+		for _, engine := range []Engine{Evaluator, VM} {
+			if engine == VM && strings.Contains(tt.src, "jotokhon") {
+				// the compiler does not support loops yet
+				continue
+			}
+
+			ctx, cancel := context.Background(), func() {}
+			if tt.ctx != nil {
+				ctx, cancel = tt.ctx()
+			}
+
+			it := New(WithStderr(io.Discard), WithEngine(engine), WithLimits(tt.limits))
+			_, err := it.Run(ctx, tt.src)
+			cancel()
+
+			var rerr *RuntimeError
+			if !errors.As(err, &rerr) {
+				t.Errorf("tests[%d] engine %d -> expected runtime error, got %v", i, engine, err)
+			}
+
+			var lerr *object.LimitError
+			if !errors.As(err, &lerr) {
+				t.Errorf("tests[%d] engine %d -> expected limit error, got %v", i, engine, err)
+			}
+		}
+	}
+}
+
+func TestAllocCountsOnlyStrings(t *testing.T) {
+	src := `dhori x = 0
+	jotokhon (x < 10000) dhori x = x + 1 sesh
+	x`
+
+	it := New(WithStderr(io.Discard), WithLimits(object.Limits{MaxAlloc: 1024}))
+	result, err := it.Run(context.Background(), src)
+	if err != nil {
+		t.Fatalf("numbers should not use the allocation budget: %v", err)
+	}
+
+	if result.Inspect() != "10000" {
+		t.Errorf("expected 10000, got %s", result.Inspect())
+	}
+}
+
+func TestLimitInShow(t *testing.T) {
+	src := `dhori f = ekti kaj(n) f(n + 1) sesh
+	dekhau(f(0))
+	dekhau("after")`
+
+	for _, engine := range []Engine{Evaluator, VM} {
+		var out bytes.Buffer
+		it := New(WithStdout(&out), WithStderr(io.Discard), WithEngine(engine), WithLimits(object.Limits{MaxCallDepth: 50}))
+		if _, err := it.Run(context.Background(), src); err == nil {
+			t.Errorf("engine %d -> expected an error", engine)
+		}
+
+		if out.Len() > 0 {
+			t.Errorf("engine %d -> the program went on after the error: %q", engine, out.String())
+		}
+	}
+}
+
+func TestPanicBecomesError(t *testing.T) {
+	it := New(WithStderr(io.Discard))
+	it.Register("boom", func() { panic("boom") })
+
+	if _, err := it.Eval(context.Background(), `boom()`); err == nil {
+		t.Errorf("expected panic to become an error")
+	}
+}
+
+func TestInput(t *testing.T) {
+	src := `dhori s = anoyon "std"
+	dhori ক = s.লাইন_পড়ো("প্রথম: ")
+	dhori খ = s.লাইন_পড়ো("দ্বিতীয়: ")
+	ক + খ`
+
+	prompts := []string{}
+	input := func(prompt string) (string, error) {
+		prompts = append(prompts, prompt)
+		return strings.TrimSpace(prompt), nil
+	}
+
+	for _, engine := range []Engine{Evaluator, VM} {
+		out := bytes.Buffer{}
+		it := New(WithEngine(engine), WithStdout(&out), WithInputLines("এক", "দুই"))
+		res, err := it.Run(context.Background(), src)
+		if err != nil || res.Inspect() != "একদুই" {
+			t.Errorf("engine %d -> expected \"একদুই\", got %v, %v", engine, res, err)
+		}
+
+		if out.String() != "প্রথম: দ্বিতীয়: " {
+			t.Errorf("engine %d -> prompts not written to stdout; got %q", engine, out.String())
+		}
+
+		prompts = nil
+		out.Reset()
+		it = New(WithEngine(engine), WithStdout(&out), WithInput(input))
+		res, err = it.Run(context.Background(), src)
+		if err != nil || res.Inspect() != "প্রথম:দ্বিতীয়:" {
+			t.Errorf("engine %d -> expected the callback result, got %v, %v", engine, res, err)
+		}
+
+		if len(prompts) != 2 || out.Len() != 0 {
+			t.Errorf("engine %d -> prompts should only go to the callback; got %v, %q", engine, prompts, out.String())
+		}
+
+		it = New(WithEngine(engine), WithInputLines("এক"), WithStderr(io.Discard))
+		if _, err := it.Run(context.Background(), src); err == nil || !hasErrMsg(err, "STDIN_READ_FAILED") {
+			t.Errorf("engine %d -> expected error at the end of input, got %v", engine, err)
+		}
+	}
+}
+
+func TestNormalizedIdentifiers(t *testing.T) {
+	// the variable is defined with the precomposed য় and used with য and
+	// a nukta, as typed by another keyboard
+	src := "dhori ভা\u09dfা = ৩\n" +
+		"dhori র\u200dব = ভা\u09af\u09bcা + 1\n" +
+		"র\u200dব"
+
+	for _, engine := range []Engine{Evaluator, VM} {
+		it := New(WithEngine(engine), WithStderr(io.Discard))
+		if err := it.Register("প\u09dcা", func() string { return "ok" }); err != nil {
+			t.Fatalf("engine %d -> unexpected error %s", engine, err)
+		}
+
+		res, err := it.Run(context.Background(), src)
+		if err != nil {
+			t.Fatalf("engine %d -> unexpected error %s", engine, err)
+		}
+
+		if res.Inspect() != "4" {
+			t.Errorf("engine %d -> expected 4, got %s", engine, res.Inspect())
+		}
+
+		res, err = it.Eval(context.Background(), "প\u09a1\u09bcা()")
+		if err != nil {
+			t.Fatalf("engine %d -> unexpected error %s", engine, err)
+		}
+
+		if res.Inspect() != "ok" {
+			t.Errorf("engine %d -> expected ok, got %s", engine, res.Inspect())
+		}
+	}
+}
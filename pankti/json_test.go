@@ -0,0 +1,38 @@
+package pankti
+
+import (
+	"testing"
+)
+
+func TestJSONModule(t *testing.T) {
+	tests := []engineTest{
+		{`j.decode("[1, 2.5, true, null, \"ক\"]")`, "[1, 2.5, true, null, ক]"},
+		{`j.পড়ো("123456789012345678901234567890") + 1`, "123456789012345678901234567891"},
+		{`j.decode("\{\"নাম\": \{\"ক\": [1]\}\}")["নাম"]["ক"][0]`, "1"},
+		{`j.decode("  \"x\"\n")`, "x"},
+		{`j.encode([1, 2.5, সত্য, "a\"b<c", [], {}])`, `[1,2.5,true,"a\"b<c",[],{}]`},
+		{`j.লেখো({"খ": 2, "ক": 1, 3: "গ"})`, `{"3":"গ","ক":1,"খ":2}`},
+		{`j.encode({"a": [1]}, {"pretty": sotto})`, "{\n  \"a\": [\n    1\n  ]\n}"},
+		{`j.encode(123456789012345678901234567890)`, "123456789012345678901234567890"},
+		{`j.encode(0.1)`, "0.1"},
+		{`j.encode({"b": 1, "a": 2, "c": {"z": 0, "y": 0}})`, `{"a":2,"b":1,"c":{"y":0,"z":0}}`},
+		{`j.encode(j.decode("\{\"a\":[1,\{\"b\":null\}]\}"))`, `{"a":[1,{"b":null}]}`},
+	}
+
+	runBoth(t, "dhori j = anoyon \"জেসন\"\n", tests)
+}
+
+func TestJSONErrors(t *testing.T) {
+	failBoth(t, "dhori j = anoyon \"json\"\n", []string{
+		`j.decode("[1, 2")`,
+		`j.decode("1 2")`,
+		`j.encode(একটি কাজ() 1 শেষ)`,
+		`j.encode([1, j.encode])`,
+		`j.encode(1, {"indent": সত্য})`,
+		`j.encode(1, {"pretty": 1})`,
+		`j.encode({"b": 1, "a": 2}, {"sort_keys": mittha})`,
+		`j.encode({"b": 1}, {"চাবি_সাজাও": sotto})`,
+		`j.encode({1: "a", "1": "b"})`,
+		`j.encode([{"x": 1, "y": {sotto: 1, "true": 2}}])`,
+	})
+}
@@ -0,0 +1,83 @@
+package pankti
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"go.cs.palashbauri.in/pankti/number"
+)
+
+func TestNumberLiterals(t *testing.T) {
+	tests := []engineTest{
+		{`১_০০_০০০ == 100000`, "true"},
+		{`0x1F == 31`, "true"},
+		{`০x১F == ৩১`, "true"},
+		{`0b1010 + 0b0101 == 15`, "true"},
+		{`1.5e3 == 1500.0`, "true"},
+		{`25e-1 == 2.5`, "true"},
+	}
+
+	runBoth(t, "", tests)
+}
+
+func TestMalformedNumber(t *testing.T) {
+	it := New(WithStderr(io.Discard))
+
+	_, err := it.Run(context.Background(), `dhori ক = ১__০০`)
+	var perr *ParseError
+	if !errors.As(err, &perr) || !hasErrMsg(err, "MALFORMED_NUMBER") {
+		t.Errorf("expected MALFORMED_NUMBER error, got %v", err)
+	}
+}
+
+func TestBengaliDigits(t *testing.T) {
+	src := `dhori s = anoyon "string"
+dekhau(১২ + 3, [1.5, 2])
+dekhau("মোট: {10 * 2}")
+dekhau(s.পরিবর্তন(42))
+`
+	expected := "১৫[১.৫, ২]\nমোট: ২০\n৪২\n"
+
+	for _, engine := range []Engine{Evaluator, VM} {
+		var out bytes.Buffer
+		it := New(WithEngine(engine), WithStdout(&out), WithDigits(number.BengaliDigits))
+		if _, err := it.Run(context.Background(), src); err != nil {
+			t.Errorf("engine %d -> unexpected error %s", engine, err)
+			continue
+		}
+
+		if out.String() != expected {
+			t.Errorf("engine %d -> expected %q, got %q", engine, expected, out.String())
+		}
+	}
+}
+
+func TestDigitConversion(t *testing.T) {
+	tests := []engineTest{
+		{`s.বাংলা_অঙ্ক(2023)`, "২০২৩"},
+		{`s.বাংলা_অঙ্ক("1.5 kg")`, "১.৫ kg"},
+		{`s.ইংরেজি_অঙ্ক("১২ টা")`, "12 টা"},
+		{`s.ইংরেজি_অঙ্ক(৭)`, "7"},
+	}
+
+	runBoth(t, "dhori s = anoyon \"string\"\n", tests)
+}
+
+func TestBengaliDigitsInErrors(t *testing.T) {
+	var errOut bytes.Buffer
+	it := New(WithStderr(&errOut), WithDigits(number.BengaliDigits))
+
+	_, err := it.Run(context.Background(), `dhori f = ekti kaj(a, b) ferao(a) sesh
+f(1)`)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	if !strings.Contains(err.Error()+errOut.String(), "২") {
+		t.Errorf("expected Bengali digits in the error, got %q", err.Error()+errOut.String())
+	}
+}
@@ -0,0 +1,118 @@
+package pankti
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.cs.palashbauri.in/pankti/pack"
+)
+
+func TestPacks(t *testing.T) {
+	as, _ := pack.Get("assamese")
+	trp, _ := pack.Get("kokborok")
+	// a pack given by the user, which is not registered
+	user, err := pack.Parse("name = user\nbase = bengali\n[keywords]\nFUNCTION = কাম\nRETURN = উভতাই\nSHOW = দেখুওৱা")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		src      string
+		pack     *pack.Pack
+		expected string
+	}{
+		{"# ভাষা: অসমীয়া\nধৰা যোগ = এটা কাম(ক, খ) ঘূৰোৱা(ক + খ) শেষ\nদেখুওৱা(যোগ(১, ২))", nil, "3\n"},
+		{"ধৰা ক = যদি (মিছা) তেন্তে ১ নহলে ২ শেষ\nদেখুওৱা(ক)", as, "2\n"},
+		{"# ভাষা: ককবরক\nমুংরি যোগ = কাইসা সামুং(ক, খ) ফাইফিন(ক + খ) শেষ\nনুক(যোগ(১, ২))", nil, "3\n"},
+		{"মুংরি ক = যদি (কাহাময়া) অবখে ১ নাইলে ২ শেষ\nনুক(ক)", trp, "2\n"},
+		{"মুংরি স = বাই \"string\"\nনুক(স.উল্টো(\"কখ\"))", trp, "খক\n"},
+		{"dhori f = ekti কাজ() ফেরাও(\"হয়\") sesh\nদেখাও(f())", nil, "হয়\n"},
+		{"dhori f = ekti কাম() উভতাই(\"হয়\") sesh\nদেখুওৱা(f())", user, "হয়\n"},
+	}
+
+	for _, engine := range []Engine{Evaluator, VM} {
+		for i, tt := range tests {
+			var out bytes.Buffer
+			it := New(WithEngine(engine), WithStdout(&out), WithStderr(&out), WithPack(tt.pack))
+			if _, err := it.Run(context.Background(), tt.src); err != nil {
+				t.Errorf("engine %d, tests[%d] -> unexpected error %s", engine, i, err)
+				continue
+			}
+
+			if out.String() != tt.expected {
+				t.Errorf("engine %d, tests[%d] -> expected %q, got %q", engine, i, tt.expected, out.String())
+			}
+		}
+	}
+
+}
+
+func TestPackMessages(t *testing.T) {
+	var errOut bytes.Buffer
+	it := New(WithStderr(&errOut))
+
+	_, err := it.Run(context.Background(), "# ভাষা: অসমীয়া\nধৰা = ১")
+	if err == nil || !strings.Contains(err.Error(), "পোৱা উচিত আছিল") || !strings.Contains(err.Error(), "`নাম`") {
+		t.Errorf("expected an Assamese message, got %v", err)
+	}
+
+	_, err = it.Run(context.Background(), "dhori = 1")
+	if err == nil || !strings.Contains(err.Error(), "পাওয়া উচিত ছিল") {
+		t.Errorf("expected the Bengali messages back, got %v", err)
+	}
+
+	res, err := it.Run(context.Background(), "# ভাষা: অসমীয়া\nধৰা স = অনা \"std\"\nস.প্রকার(\"ক\")")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	if res.Inspect() != "ষ্ট্ৰিং" {
+		t.Errorf("expected the Assamese name of the type, got %s", res.Inspect())
+	}
+
+}
+
+func TestPacksInParallel(t *testing.T) {
+	as, _ := pack.Get("assamese")
+
+	tests := []struct {
+		pack *pack.Pack
+		src  string
+		// a part of the error message in the language of the pack
+		expected string
+	}{
+		{nil, "dhori = 1", "পাওয়া উচিত ছিল"},
+		{as, "ধৰা = ১", "পোৱা উচিত আছিল"},
+		{nil, `dhori s = anoyon "string"
+s.reverse(১)`, "স্ট্রিং"},
+		{as, `ধৰা স = অনা "string"
+স.reverse(১)`, "ষ্ট্ৰিং"},
+		{nil, "dhori f = ekti kaj(ক) ক sesh\nf(১, ২)", "পরামর্শ: "},
+		{as, "ধৰা ফ = এটা কাম(ক) ক শেষ\nফ(১, ২)", "পৰামৰ্শ: "},
+	}
+
+	var wg sync.WaitGroup
+	for i, tt := range tests {
+		for _, engine := range []Engine{Evaluator, VM} {
+			wg.Add(1)
+			go func(i int, engine Engine, pk *pack.Pack, src, expected string) {
+				defer wg.Done()
+
+				it := New(WithEngine(engine), WithStderr(io.Discard), WithPack(pk))
+				for n := 0; n < 20; n++ {
+					_, err := it.Run(context.Background(), src)
+					if err == nil || !strings.Contains(err.Error(), expected) {
+						t.Errorf("engine %d, tests[%d] -> expected %q in the error, got %v", engine, i, expected, err)
+						return
+					}
+				}
+			}(i, engine, tt.pack, tt.src, tt.expected)
+		}
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,248 @@
+package pankti
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.cs.palashbauri.in/pankti/errs"
+	"go.cs.palashbauri.in/pankti/object"
+	"go.cs.palashbauri.in/pankti/vfs"
+)
+
+func TestPermissions(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "ক.txt"), []byte("লেখা"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "মডিউল.pank"), []byte(`dhori x = "এক"`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		perms object.Permissions
+		src   string
+		// expected error, or empty if the program must succeed
+		err      string
+		expected string
+	}{
+		{object.Sandboxed(), `dhori f = anoyon "file"
+		f.পড়ো("ক.txt")`, "PERM_NO_FS", ""},
+		{object.Sandboxed(), `dhori s = anoyon "sys"
+		s.ওএস()`, "PERM_NO_OS_INFO", ""},
+		{object.Sandboxed(), `dhori s = anoyon "std"
+		s.লাইন_পড়ো("> ")`, "PERM_NO_STDIN", ""},
+		{object.Sandboxed(), `dhori m = anoyon "` + filepath.Join(root, "মডিউল.pank") + `"`, "PERM_NO_FS", ""},
+		{object.Permissions{FS: object.FSReadOnly}, `dhori f = anoyon "file"
+		f.পড়ো("` + filepath.Join(root, "ক.txt") + `")`, "", "লেখা"},
+		{object.Permissions{FS: object.FSReadOnly}, `dhori f = anoyon "file"
+		f.লেখো("` + filepath.Join(root, "খ.txt") + `", "লেখা")`, "PERM_READ_ONLY", ""},
+		{object.Permissions{FSRoot: root}, `dhori f = anoyon "file"
+		f.পড়ো("ক.txt")`, "", "লেখা"},
+		{object.Permissions{FSRoot: root}, `dhori f = anoyon "file"
+		f.পড়ো("../ক.txt")`, "PERM_OUTSIDE_ROOT", ""},
+		{object.Permissions{FSRoot: root}, `dhori f = anoyon "file"
+		f.আছে_কি("/")`, "PERM_OUTSIDE_ROOT", ""},
+		{object.Permissions{FSRoot: root}, `dhori m = anoyon "মডিউল.pank"
+		m.x`, "", "এক"},
+	}
+
+	for _, engine := range []Engine{Evaluator, VM} {
+		for i, tt := range tests {
+			it := New(WithEngine(engine), WithPermissions(tt.perms), WithStderr(io.Discard))
+			res, err := it.Run(context.Background(), tt.src)
+
+			if len(tt.err) > 0 {
+				if err == nil || !hasErrMsg(err, tt.err) {
+					t.Errorf("engine %d, tests[%d] -> expected %s error, got %v", engine, i, tt.err, err)
+				}
+				continue
+			}
+
+			if err != nil {
+				t.Errorf("engine %d, tests[%d] -> unexpected error %s", engine, i, err)
+				continue
+			}
+
+			if res.Inspect() != tt.expected {
+				t.Errorf("engine %d, tests[%d] -> expected %q, got %q", engine, i, tt.expected, res.Inspect())
+			}
+		}
+	}
+}
+
+func TestListDirInsideRoot(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "ক.txt"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	it := New(WithPermissions(object.Permissions{FSRoot: root}))
+	res, err := it.Run(context.Background(), `dhori f = anoyon "file"
+	f.ফাইলের_তালিকা(".")`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(res.Inspect(), root) {
+		t.Errorf("listing reveals the root directory: %s", res.Inspect())
+	}
+}
+
+func TestWriteErrorInsideRoot(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "ফোল্ডার"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, src := range []string{
+		`dhori f = anoyon "file"
+		f.লেখো("ফোল্ডার", "লেখা")`,
+		`dhori c = anoyon "csv"
+		c.write_file("ফোল্ডার", [[1, 2]])`,
+	} {
+		it := New(WithPermissions(object.Permissions{FSRoot: root}), WithStderr(io.Discard))
+		_, err := it.Run(context.Background(), src)
+		if err == nil || !hasErrMsg(err, "FAILED_TO_WRITE_FILE") {
+			t.Errorf("tests[%d] -> expected FAILED_TO_WRITE_FILE error, got %v", i, err)
+			continue
+		}
+
+		if strings.Contains(err.Error(), root) {
+			t.Errorf("tests[%d] -> error reveals the root directory: %s", i, err)
+		}
+	}
+}
+
+func TestSymlinkOutsideRoot(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "root")
+	outside := filepath.Join(dir, "outside")
+	for _, d := range []string{root, outside} {
+		if err := os.Mkdir(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(outside, filepath.Join(root, "link")); err != nil {
+		t.Skip("symbolic links are not supported:", err)
+	}
+
+	for i, src := range []string{
+		`dhori f = anoyon "file"
+		f.লেখো("link/new.txt", "লেখা")`,
+		`dhori f = anoyon "file"
+		f.লেখো("link/dir/new.txt", "লেখা")`,
+		`dhori c = anoyon "csv"
+		c.write_file("link/new.csv", [[1, 2]])`,
+	} {
+		it := New(WithPermissions(object.Permissions{FSRoot: root}), WithStderr(io.Discard))
+		if _, err := it.Run(context.Background(), src); err == nil || !hasErrMsg(err, "PERM_OUTSIDE_ROOT") {
+			t.Errorf("tests[%d] -> expected PERM_OUTSIDE_ROOT error, got %v", i, err)
+		}
+	}
+
+	if entries, _ := os.ReadDir(outside); len(entries) > 0 {
+		t.Errorf("files were written outside of the root: %v", entries)
+	}
+}
+
+func TestRootNotDeleted(t *testing.T) {
+	// a relative root, which is only found from the current directory on
+	// the filesystem of the operating system
+	root, err := os.MkdirTemp(".", "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	root = filepath.Base(root)
+	t.Cleanup(func() { os.RemoveAll(root) })
+
+	if err := os.WriteFile(filepath.Join(root, "ক.txt"), []byte("লেখা"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	srcs := []string{
+		`f.মুছুন(".")`,
+		`f.মুছুন("")`,
+		`f.মুছুন("ফোল্ডার/..")`,
+		`f.নাম_পরিবর্তন(".", "নতুন")`,
+	}
+
+	// the root is found the same way for OS and *OS
+	for _, fsys := range []vfs.FS{vfs.OS{}, &vfs.OS{}} {
+		for _, engine := range []Engine{Evaluator, VM} {
+			for i, src := range srcs {
+				it := New(WithEngine(engine), WithFS(fsys), WithPermissions(object.Permissions{FSRoot: root}), WithStderr(io.Discard))
+				if _, err := it.Run(context.Background(), "dhori f = anoyon \"file\"\n"+src); err == nil || !hasErrMsg(err, "ROOT_NOT_CHANGEABLE") {
+					t.Errorf("%T, engine %d, tests[%d] -> expected ROOT_NOT_CHANGEABLE error, got %v", fsys, engine, i, err)
+				}
+			}
+
+			it := New(WithEngine(engine), WithFS(fsys), WithPermissions(object.Permissions{FSRoot: root}))
+			res, err := it.Run(context.Background(), "dhori f = anoyon \"file\"\nf.পড়ো(\"ক.txt\")")
+			if err != nil || res.Inspect() != "লেখা" {
+				t.Errorf("%T, engine %d -> expected the file inside the root, got %v, %v", fsys, engine, res, err)
+			}
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "ক.txt")); err != nil {
+		t.Errorf("the root was changed: %v", err)
+	}
+}
+
+// brokenDirFS is a filesystem whose directories can not be read
+type brokenDirFS struct {
+	vfs.FS
+}
+
+func (brokenDirFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrPermission}
+}
+
+func TestFileErrorsInsideRoot(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "ফোল্ডার"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		src string
+		err string
+		// the error of the filesystem, with the path the program used
+		fsErr string
+	}{
+		{`f.পড়ো("নেই.txt")`, "FAILED_TO_READ_FILE", "open নেই.txt: "},
+		{`f.ফাইলের_তালিকা("ফোল্ডার")`, "DIR_LIST_FAILED", "readdir ফোল্ডার: "},
+	}
+
+	for _, engine := range []Engine{Evaluator, VM} {
+		for i, tt := range tests {
+			it := New(WithEngine(engine), WithFS(brokenDirFS{vfs.OS{}}), WithPermissions(object.Permissions{FSRoot: root}), WithStderr(io.Discard))
+			_, err := it.Run(context.Background(), "dhori f = anoyon \"file\"\n"+tt.src)
+			if err == nil || !hasErrMsg(err, tt.err) {
+				t.Errorf("engine %d, tests[%d] -> expected %s error, got %v", engine, i, tt.err, err)
+				continue
+			}
+
+			if strings.Contains(err.Error(), root) || !strings.Contains(err.Error(), tt.fsErr) {
+				t.Errorf("engine %d, tests[%d] -> expected the error of the filesystem, got %v", engine, i, err)
+			}
+		}
+	}
+}
+
+// hasErrMsg reports if err has the message of the error key, with any
+// value in place of the verbs
+func hasErrMsg(err error, key string) bool {
+	for _, part := range strings.Split(errs.Errs[key], "%s") {
+		if !strings.Contains(err.Error(), part) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,54 @@
+package pankti
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRegexModule(t *testing.T) {
+	tests := []engineTest{
+		{`r.মেলে("^[০-৯]+$", "২০২৪")`, "true"},
+		{`r.match(r.compile("[0-9]+"), "abc")`, "false"},
+		{`r.compile("ক+")`, "/ক+/"},
+		{`r.খোঁজো("[0-9]+", "a12b345")`, "12"},
+		{`r.find("[0-9]+", "abc")`, "null"},
+		{`r.সব_খোঁজো("[0-9]+", "a1 b22 c333")`, "[1, 22, 333]"},
+		{`r.find_all("x", "abc")`, "[]"},
+		{`r.দল("(\\w+)@(\\w+)", "mail x@y now")`, "[x@y, x, y]"},
+		{`r.groups("(a)(x)?", "a")`, "[a, a, null]"},
+		{`r.groups_all("(\\d)(\\d)", "12 34")`, "[[12, 1, 2], [34, 3, 4]]"},
+		{`r.নামের_দল("(?P<user>\\w+)@(?P<host>\\w+)", "x@y")["user"]`, "x"},
+		{`r.named_groups("(?P<host>\\w+)", "")`, "null"},
+		{`r.প্রতিস্থাপন("([a-z])([0-9])", "a1 b2", "$2$1")`, "1a 2b"},
+		{`r.replace("[0-9]+", "a1 b22", একটি কাজ(ম) "<" + ম + ">" শেষ)`, "a<1> b<22>"},
+		{`r.replace("[০-৯]", "১২", একটি কাজ(ম) ইংরেজি(ম) শেষ)`, "12"},
+		{`r.খণ্ড(",\\s*", "ক, খ,গ")`, "[ক, খ, গ]"},
+		// `{` starts interpolation in a normal string, so quantifiers are
+		// written in a backtick string or escaped with \{
+		{"r.find(`[0-9]{3}`, \"ab12345\")", "123"},
+		{"r.find_all(`a{2,3}`, \"a aa aaaa\")", "[aa, aaa]"},
+		{`r.match("^ক\{2\}$", "কক")`, "true"},
+	}
+
+	runBoth(t, "dhori r = anoyon \"রেজেক্স\"\ndhori s = anoyon \"string\"\ndhori ইংরেজি = s.ascii_digits\n", tests)
+}
+
+func TestRegexErrors(t *testing.T) {
+	failBoth(t, "dhori r = anoyon \"regex\"\n", []string{
+		`r.match("(", "x")`,
+		`r.compile("[ক-")`,
+		`r.replace("x", "x", একটি কাজ(ম) r.find("(", ম) শেষ)`,
+		`r.replace("x", "x", একটি কাজ(ক, খ) ক শেষ)`,
+	})
+
+	// the error points at the call of the builtin
+	for _, engine := range []Engine{Evaluator, VM} {
+		it := New(WithEngine(engine), WithStderr(io.Discard))
+		_, err := it.Run(context.Background(), "dhori r = anoyon \"regex\"\n\nr.match(\"(\", \"x\")")
+		if err == nil || !strings.Contains(err.Error(), "3 | r.match(") {
+			t.Errorf("engine %d -> expected the error at the call, got %v", engine, err)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package pankti
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"go.cs.palashbauri.in/pankti/object"
+)
+
+func TestInterpolation(t *testing.T) {
+	tests := []engineTest{
+		{`dhori নাম = "পলাশ"
+		"নাম: {নাম}!"`, "নাম: পলাশ!"},
+		{`dhori ক = sotto
+		"{ক}"`, "true"},
+		{`dhori ক = "ক"
+		dhori খ = "খ"
+		"{ক + খ}, {[ক, খ]}"`, "কখ, [ক, খ]"},
+		{`dhori f = ekti kaj(x) ferao(x + "!") sesh
+		"{f("হ্যাঁ")} {{"ক": "খ"}["ক"]}"`, "হ্যাঁ! খ"},
+		{`"বাইরে {"ভিতরে {"আরও ভিতরে"}"}"`, "বাইরে ভিতরে আরও ভিতরে"},
+		{`"\{ক\}"`, "{ক}"},
+		{"`{ক}`", "{ক}"},
+	}
+
+	runBoth(t, "", tests)
+}
+
+func TestInterpolationErrors(t *testing.T) {
+	for i, src := range []string{`"ক {খ`, `"ক {খ গ}"`, `"ক {খ} গ`} {
+		it := New(WithStderr(io.Discard))
+
+		_, err := it.Run(context.Background(), src)
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("tests[%d] -> expected parse error, got %v", i, err)
+		}
+	}
+}
+
+func TestStringIndexing(t *testing.T) {
+	tests := []engineTest{
+		{`দৈর্ঘ্য("ক্ষমা")`, "2"},
+		{`len("কি")`, "1"},
+		{`rune_len("ক্ষমা")`, "5"},
+		{`বাইট_দৈর্ঘ্য("ক্ষমা")`, "15"},
+		{`"ক্ষমা"[0]`, "ক্ষ"},
+		{`"ক্ষমা"[১]`, "মা"},
+		{`"ক্ষমা"[2]`, "null"},
+		{`"নমস্কার"[1:3]`, "মস্কা"},
+		{`"নমস্কার"[:1]`, "ন"},
+		{`"নমস্কার"[2:]`, "স্কার"},
+		{`"abc"[-1:10]`, "abc"},
+		{`"abc"[2:1]`, ""},
+		{`[1, 2, 3, 4][1:3]`, "[2, 3]"},
+		{`[1, 2, 3, 4][:2]`, "[1, 2]"},
+		{`dhori a = [1, 2]
+		dhori b = a[:]
+		a[0] == b[0]`, "true"},
+	}
+
+	runBoth(t, "", tests)
+}
+
+func TestStringIndexErrors(t *testing.T) {
+	failBoth(t, "", []string{`"ক"[1.5]`, `"ক"["x":]`, `5[1:2]`})
+}
+
+func TestStrayBrace(t *testing.T) {
+	for i, src := range []string{`dekhau("{")`, `dekhau("ক { খ")`, `dekhau("{}")`} {
+		it := New(WithStderr(io.Discard))
+		_, err := it.Run(context.Background(), src)
+
+		var perr *ParseError
+		if !errors.As(err, &perr) || len(perr.Errs) != 1 {
+			t.Errorf("tests[%d] -> expected one parse error, got %v", i, err)
+			continue
+		}
+
+		if perr.Errs[0].GetToken().Literal != "{" || !strings.Contains(err.Error(), `\{`) {
+			t.Errorf("tests[%d] -> expected the error at the `{` with a hint, got %v", i, err)
+		}
+	}
+}
+
+func TestNumberInterpolationWarning(t *testing.T) {
+	tests := []struct {
+		src  string
+		warn bool
+	}{
+		{`"\\d{3}"`, true},
+		{`"ক{১০}খ"`, true},
+		{`"{1 + 2}"`, false},
+		{`"\\d\{3\}"`, false},
+		{"`\\d{3}`", false},
+	}
+
+	for i, tt := range tests {
+		errOut := bytes.Buffer{}
+		it := New(WithStderr(&errOut))
+
+		// the program still runs, with the number in the string
+		if _, err := it.Run(context.Background(), tt.src); err != nil {
+			t.Errorf("tests[%d] -> unexpected error %s", i, err)
+			continue
+		}
+
+		if warned := strings.Contains(errOut.String(), `\{`); warned != tt.warn {
+			t.Errorf("tests[%d] -> expected a warning %v, got %q", i, tt.warn, errOut.String())
+		}
+	}
+}
+
+func TestStringModule(t *testing.T) {
+	tests := []engineTest{
+		{`s.আছে_কি("নমস্কার", "স্কা")`, "true"},
+		{`s.contains("abc", "x")`, "false"},
+		{`s.অবস্থান("ক্ষমা করো", "করো")`, "3"},
+		{`s.index_of("abc", "z")`, "-1"},
+		{`s.শুরুতে_আছে("নমস্কার", "নম")`, "true"},
+		{`s.ends_with("abc", "bc")`, "true"},
+		{`s.প্রতিস্থাপন("a-b-c", "-", "+")`, "a+b-c"},
+		{`s.replace_all("a-b-c", "-", "+")`, "a+b+c"},
+		{`s.ছাঁটো("  ক\n")`, "ক"},
+		{`s.trim_left("xxকxx", "x")`, "কxx"},
+		{`s.ডানে_ছাঁটো("কখখ", "খ")`, "ক"},
+		{`s.upper("Pankti ক")`, "PANKTI ক"},
+		{`s.ছোট_হাতের("ABC")`, "abc"},
+		{`s.পুনরাবৃত্তি("হা", 3)`, "হাহাহা"},
+		{`s.বাঁয়ে_ভরাট("৭", 3, "০")`, "০০৭"},
+		{`s.pad_right("ক্ষ", 3)`, "ক্ষ  "},
+		{`s.উল্টো("ক্ষমা")`, "মাক্ষ"},
+		{`s.ফরম্যাট("%s পেয়েছে %d, %.1f%%", "রহিম", 42, 87.56)`, "রহিম পেয়েছে 42, 87.6%"},
+		{`s.বাংলা_ফরম্যাট("%s পেয়েছে %d, %.1f%%", "রহিম", 42, 87.56)`, "রহিম পেয়েছে ৪২, ৮৭.৬%"},
+		{`s.format("%05d|%v", 42, [1, 2])`, "00042|[1, 2]"},
+		{`s.format("%s", "100%!")`, "100%!"},
+		{`s.format("%d%% %5.1f %t", 5, 2.25, sotto)`, "5%   2.2 true"},
+	}
+
+	runBoth(t, "dhori s = anoyon \"string\"\n", tests)
+
+	failBoth(t, "dhori s = anoyon \"string\"\n", []string{
+		`s.format("%d", "x")`,
+		`s.format("%d %d", 1)`,
+		`s.format("%s", "a", "b")`,
+		`s.format("%t", 1)`,
+		`s.format("%*d", 3, 1)`,
+		`s.format("%", 1)`,
+		`s.repeat("x", -1)`,
+	})
+}
+
+func TestStringAllocLimit(t *testing.T) {
+	limits := object.Limits{MaxAlloc: 1 << 20}
+
+	for _, engine := range []Engine{Evaluator, VM} {
+		for i, src := range []string{
+			`s.repeat("ab", 100000000)`,
+			`s.pad_left("x", 100000000)`,
+			`s.pad_right("x", 100000000, "ক্ষ")`,
+		} {
+			it := New(WithEngine(engine), WithStderr(io.Discard), WithLimits(limits))
+			_, err := it.Run(context.Background(), "dhori s = anoyon \"string\"\n"+src)
+
+			var lerr *object.LimitError
+			if !errors.As(err, &lerr) {
+				t.Errorf("engine %d, tests[%d] -> expected a limit error, got %v", engine, i, err)
+			}
+		}
+
+		it := New(WithEngine(engine), WithStderr(io.Discard), WithLimits(limits))
+		if _, err := it.Run(context.Background(), "dhori s = anoyon \"string\"\ns.repeat(\"ab\", 1000)"); err != nil {
+			t.Errorf("engine %d -> unexpected error %s", engine, err)
+		}
+	}
+}
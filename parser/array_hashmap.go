@@ -36,12 +36,34 @@ func (p *Parser) parseHashLit() ast.Expr {
 
 }
 
+// parseIndexExpr parses an index, such as a[1], or a slice, such as
+// a[1:3], a[:3] or a[1:]
 func (p *Parser) parseIndexExpr(l ast.Expr) ast.Expr {
-	e := &ast.IndexExpr{Token: p.curTok, Left: l}
+	tok := p.curTok
 
 	p.nextToken()
 
-	e.Index = p.parseExpr(LOWEST)
+	var index ast.Expr
+	if !p.isCurToken(token.COLON) {
+		index = p.parseExpr(LOWEST)
+
+		if !p.isPeekToken(token.COLON) {
+			if !p.peek(token.RS_BRACKET) {
+				return nil
+			}
+
+			return &ast.IndexExpr{Token: tok, Left: l, Index: index}
+		}
+
+		p.nextToken()
+	}
+
+	e := &ast.SliceExpr{Token: tok, Left: l, Start: index}
+
+	if !p.isPeekToken(token.RS_BRACKET) {
+		p.nextToken()
+		e.End = p.parseExpr(LOWEST)
+	}
 
 	if !p.peek(token.RS_BRACKET) {
 		return nil
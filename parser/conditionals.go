@@ -1,7 +1,6 @@
 package parser
 
 import (
-	log "github.com/sirupsen/logrus"
 	"go.cs.palashbauri.in/pankti/ast"
 	"go.cs.palashbauri.in/pankti/token"
 )
@@ -34,8 +33,10 @@ func (p *Parser) parseIfExpr() ast.Expr {
 		//}
 		p.nextToken()
 	}
+	tb.SetSpan(token.Span{Start: tb.Token.Span.Start, End: p.prevEnd})
 
 	p.nextToken()
+	elseStart := p.curTok.Span.Start
 
 	if !p.isCurToken(token.END) && !p.isCurToken(token.EOF) {
 		s := p.parseStmt()
@@ -44,6 +45,7 @@ func (p *Parser) parseIfExpr() ast.Expr {
 		//}
 		p.nextToken()
 	}
+	eb.SetSpan(token.Span{Start: elseStart, End: p.prevEnd})
 
 	exp.TrueBlock = tb
 	exp.ElseBlock = eb
@@ -54,14 +56,14 @@ func (p *Parser) parseIfExpr() ast.Expr {
 	//exp.ElseBlock = p.parseBlockStmt(token.END)
 
 	if has_else {
-		log.Info(
+		p.log.Info(
 			"IF ELSE Expr => ",
 			exp.Cond,
 			exp.TrueBlock.String(),
 			exp.ElseBlock.String(),
 		)
 	} else {
-		log.Info("IF Expr => ", exp.Cond, exp.TrueBlock.String())
+		p.log.Info("IF Expr => ", exp.Cond, exp.TrueBlock.String())
 	}
 
 	return exp
@@ -1,8 +1,9 @@
 package parser
 
 import (
-	"strconv"
+	"strings"
 
+	"go.cs.palashbauri.in/pankti/diag"
 	"go.cs.palashbauri.in/pankti/errs"
 	"go.cs.palashbauri.in/pankti/token"
 )
@@ -11,33 +12,70 @@ func (p *Parser) GetErrors() []errs.ParserError {
 	return p.errs
 }
 
+// GetWarnings returns the parts of the source which are valid but are
+// likely mistakes; they do not stop the program
+func (p *Parser) GetWarnings() []errs.ParserError {
+	return p.warnings
+}
+
+// warn adds a warning with the message key at the token t
+func (p *Parser) warn(t token.Token, key string, args ...interface{}) {
+	p.warnings = append(p.warnings, &errs.Warning{
+		Key:     key,
+		Msg:     p.msg(key),
+		Token:   t,
+		Args:    args,
+		ErrLine: p.errorLine(diag.At(t, "")),
+	})
+}
+
 func (p *Parser) peekErr(t token.TokenType) {
 	expectedToken := t
 	if len(t) > 1 {
-		expectedToken = token.TokenType(token.HumanFriendly[string(t)])
+		expectedToken = token.TokenType(p.lx.Pack().TokenName(t))
 	}
 	newerr := errs.PeekError{
+		Msg:      p.msg(errs.EXPECTED_GOT),
 		Expected: expectedToken,
 		Got:      p.peekTok,
-		ErrLine:  MakeErrorLine(p.curTok, p.lx.GetLine(p.curTok.LineNo)),
+		ErrLine:  p.errorLine(diag.At(p.peekTok, ""), diag.At(p.curTok, "এর পরে")),
 	}
 	p.errs = append(p.errs, &newerr)
 }
 
-func MakeErrorLine(t token.Token, line string) string {
-	//    fmt.Println(t.LineNo , line)
-	Lindex := t.Column - 1
+// msg returns the error message called key in the keyword pack of the
+// source
+func (p *Parser) msg(key string) string {
+	return p.lx.Pack().Msg(key)
+}
+
+// SetColor sets if the source lines in error messages are colored for a
+// terminal
+func (p *Parser) SetColor(color bool) {
+	p.renderer.Color = color
+}
 
-	RIndex := t.Column + len(t.Literal) - 1
+// errorLine renders the lines of the source marked by the labels
+func (p *Parser) errorLine(primary diag.Label, secondary ...diag.Label) string {
+	d := diag.Diagnostic{Primary: primary, Secondary: secondary}
+	return strings.TrimSuffix(p.renderer.Snippet(p.lx.Source(), d), "\n")
+}
 
-	if len(t.Literal) <= 1 {
-		RIndex = Lindex + 1
-	}
+// lexerErrs adds the errors the lexer has found since the last call
+func (p *Parser) lexerErrs() {
+	found := p.lx.Errors()
 
-	newL := line[:RIndex] + " <-- " + line[RIndex:]
-	newLine := newL[:Lindex] + " --> " + newL[Lindex:]
+	for _, e := range found[p.lexErrs:] {
+		p.errs = append(p.errs, &errs.LexerError{
+			Key:     e.Key,
+			Msg:     p.msg(e.Key),
+			Token:   e.Token,
+			Args:    e.Args,
+			ErrLine: p.errorLine(diag.At(e.Token, "")),
+		})
+	}
 
-	return strconv.Itoa(t.LineNo) + "| " + newLine
+	p.lexErrs = len(found)
 }
 
 func (p *Parser) noPrefixFunctionErr(t token.Token) {
@@ -45,13 +83,15 @@ func (p *Parser) noPrefixFunctionErr(t token.Token) {
 
 	if t.Type == token.FUNC {
 		msg = &errs.NoEktiError{
+			Msg:     p.msg(errs.NO_EKTI_BEFORE_FN),
 			Type:    t.Type,
-			ErrLine: MakeErrorLine(t, p.lx.GetLine(t.LineNo)),
+			ErrLine: p.errorLine(diag.At(t, "")),
 		}
 	} else {
 		msg = &errs.NoPrefixSuffixError{
+			Msg:     p.msg(errs.NO_PREFIX_SUFFIX_FN),
 			Token:   p.curTok,
-			ErrLine: MakeErrorLine(t, p.lx.GetLine(t.LineNo)),
+			ErrLine: p.errorLine(diag.At(t, "")),
 		}
 
 	}
@@ -1,7 +1,6 @@
 package parser
 
 import (
-	log "github.com/sirupsen/logrus"
 	"go.cs.palashbauri.in/pankti/ast"
 	"go.cs.palashbauri.in/pankti/token"
 )
@@ -26,7 +25,9 @@ func (p *Parser) parseExpr(prec int) ast.Expr {
 		return nil
 	}
 
+	start := p.curTok.Span.Start
 	leftExpr := prefix()
+	setSpan(leftExpr, p.spanFrom(start))
 
 	for !p.isPeekToken(token.SEMICOLON) && prec < p.peekPrec() {
 		infix := p.infixParseFns[p.peekTok.Type]
@@ -38,6 +39,7 @@ func (p *Parser) parseExpr(prec int) ast.Expr {
 		p.nextToken()
 
 		leftExpr = infix(leftExpr)
+		setSpan(leftExpr, p.spanFrom(start))
 	}
 
 	//fmt.Println(leftExpr)
@@ -45,6 +47,14 @@ func (p *Parser) parseExpr(prec int) ast.Expr {
 
 }
 
+// setSpan records the span of an expression; exp is nil after a syntax
+// error
+func setSpan(exp ast.Expr, sp token.Span) {
+	if s, ok := exp.(interface{ SetSpan(token.Span) }); ok {
+		s.SetSpan(sp)
+	}
+}
+
 func (p *Parser) parsePrefixExpr() ast.Expr {
 	exp := &ast.PrefixExpr{
 		Token: p.curTok,
@@ -54,7 +64,7 @@ func (p *Parser) parsePrefixExpr() ast.Expr {
 	p.nextToken()
 	exp.Right = p.parseExpr(PREFIX)
 
-	log.Info("PREFIX => ", exp.Token, exp.Right)
+	p.log.Info("PREFIX => ", exp.Token, exp.Right)
 	return exp
 }
 
@@ -70,7 +80,7 @@ func (p *Parser) parseInfixExpr(left ast.Expr) ast.Expr {
 	p.nextToken()
 	exp.Right = p.parseExpr(prec)
 
-	log.Info("INFIX => ", exp.Left, exp.Op, exp.Right)
+	p.log.Info("INFIX => ", exp.Left, exp.Op, exp.Right)
 
 	return exp
 }
@@ -3,7 +3,6 @@ package parser
 import (
 	//"fmt"
 
-	log "github.com/sirupsen/logrus"
 	"go.cs.palashbauri.in/pankti/ast"
 	"go.cs.palashbauri.in/pankti/token"
 )
@@ -30,7 +29,7 @@ func (p *Parser) parseFunc() ast.Expr {
 
 	fl.Body = p.parseBlockStmt(token.END)
 
-	log.Info("FN EXPR => ", fl.Body.String())
+	p.log.Info("FN EXPR => ", fl.Body.String())
 
 	return fl
 }
@@ -46,12 +45,14 @@ func (p *Parser) parseFuncParams() []*ast.Identifier {
 	p.nextToken()
 
 	id := &ast.Identifier{Token: p.curTok, Value: p.curTok.Literal}
+	id.SetSpan(p.curTok.Span)
 	ids = append(ids, id)
 
 	for p.isPeekToken(token.COMMA) {
 		p.nextToken()
 		p.nextToken()
 		id := &ast.Identifier{Token: p.curTok, Value: p.curTok.Literal}
+		id.SetSpan(p.curTok.Span)
 		ids = append(ids, id)
 	}
 
@@ -59,7 +60,7 @@ func (p *Parser) parseFuncParams() []*ast.Identifier {
 		return nil
 	}
 
-	log.Info("FUNC PARAMS => ", ids)
+	p.log.Info("FUNC PARAMS => ", ids)
 	return ids
 }
 
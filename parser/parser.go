@@ -1,7 +1,12 @@
 package parser
 
 import (
+	"io"
+	"strings"
+
+	"github.com/sirupsen/logrus"
 	"go.cs.palashbauri.in/pankti/ast"
+	"go.cs.palashbauri.in/pankti/diag"
 	"go.cs.palashbauri.in/pankti/errs"
 	"go.cs.palashbauri.in/pankti/lexer"
 	"go.cs.palashbauri.in/pankti/token"
@@ -43,11 +48,29 @@ type Parser struct {
 	lx      *lexer.Lexer
 	curTok  token.Token
 	peekTok token.Token
+	// where the token before curTok ends
+	prevEnd token.Pos
 
-	errs []errs.ParserError
+	errs     []errs.ParserError
+	warnings []errs.ParserError
+	renderer diag.Renderer
+	// number of lexer errors already added to errs
+	lexErrs int
 
 	prefixParseFns map[token.TokenType]prefixParseFn
 	infixParseFns  map[token.TokenType]infixParseFn
+
+	// where the parsed nodes are written to, for debugging
+	log logrus.FieldLogger
+}
+
+// silent is the logger of parsers which are not given one; it drops
+// everything, so embedders do not get debugging output on their stderr
+var silent = &logrus.Logger{
+	Out:       io.Discard,
+	Formatter: new(logrus.TextFormatter),
+	Hooks:     make(logrus.LevelHooks),
+	Level:     logrus.PanicLevel,
 }
 
 type (
@@ -59,6 +82,7 @@ func NewParser(l *lexer.Lexer) *Parser {
 
 	p := &Parser{lx: l,
 		errs: []errs.ParserError{},
+		log:  silent,
 	}
 
 	//register prefix functions
@@ -75,6 +99,7 @@ func NewParser(l *lexer.Lexer) *Parser {
 	p.regPrefix(token.WHILE, p.parseWhileExpr)
 	p.regPrefix(token.EKTI, p.parseFunc)
 	p.regPrefix(token.STRING, p.parseStringLit)
+	p.regPrefix(token.STRING_HEAD, p.parseConcatExpr)
 	p.regPrefix(token.LS_BRACKET, p.parseArrLit)
 	p.regPrefix(token.LBRACE, p.parseHashLit)
 	p.regPrefix(token.INCLUDE, p.parseIncludeExpr)
@@ -103,6 +128,12 @@ func NewParser(l *lexer.Lexer) *Parser {
 
 }
 
+// SetLogger makes the parser write the nodes it parses to l, for
+// debugging the parser
+func (p *Parser) SetLogger(l logrus.FieldLogger) {
+	p.log = l
+}
+
 func (p *Parser) regPrefix(tokenType token.TokenType, fn prefixParseFn) {
 	p.prefixParseFns[tokenType] = fn
 }
@@ -112,8 +143,10 @@ func (p *Parser) regInfix(tokenType token.TokenType, fn infixParseFn) {
 }
 
 func (p *Parser) nextToken() {
+	p.prevEnd = p.curTok.Span.End
 	p.curTok = p.peekTok
 	p.peekTok = p.lx.NextToken()
+	p.lexerErrs()
 }
 
 func (p *Parser) ParseProg() *ast.Program {
@@ -132,6 +165,9 @@ func (p *Parser) ParseProg() *ast.Program {
 		p.nextToken()
 	}
 
+	// the whole source code, up to the end of file token
+	prog.SetSpan(p.spanFrom(token.Pos{Line: 1, Column: 1}))
+
 	return prog
 }
 
@@ -146,6 +182,8 @@ func (p *Parser) parseStmt() ast.Stmt {
 	//	return p.parseIncludeStmt()
 	case token.COMMENT:
 		return p.parseComment()
+	case token.DOC_COMMENT:
+		return p.parseDocComment()
 	case token.SHOW:
 		return p.parseShowStmt()
 	default:
@@ -156,13 +194,52 @@ func (p *Parser) parseStmt() ast.Stmt {
 
 func (p *Parser) parseComment() ast.Stmt {
 
-	return &ast.Comment{
+	c := &ast.Comment{
 		Token: p.curTok,
 		Value: p.curTok.Literal,
 	}
+	c.SetSpan(p.curTok.Span)
+
+	return c
+}
+
+// parseDocComment reads the `##` lines before a `dhori` statement and
+// attaches them to it; `##` lines before anything else are comments
+func (p *Parser) parseDocComment() ast.Stmt {
+	start := p.curTok
+	lines := []string{p.curTok.Literal}
+
+	for p.isPeekToken(token.DOC_COMMENT) {
+		p.nextToken()
+		lines = append(lines, p.curTok.Literal)
+	}
+	doc := strings.Join(lines, "\n")
+
+	if !p.isPeekToken(token.LET) {
+		c := &ast.Comment{Token: start, Value: doc}
+		c.SetSpan(p.spanFrom(start.Span.Start))
+		return c
+	}
+
+	p.nextToken()
+	stmt := p.parseLetStmt()
+	if stmt != nil {
+		stmt.Doc = doc
+		if fl, ok := stmt.Value.(*ast.FunctionLit); ok {
+			fl.Doc = doc
+		}
+	}
+
+	return stmt
 }
 
 // Helper functions
+
+// spanFrom returns the span from start to the end of the current token
+func (p *Parser) spanFrom(start token.Pos) token.Span {
+	return token.Span{Start: start, End: p.curTok.Span.End}
+}
+
 func (p *Parser) isCurToken(t token.TokenType) bool {
 	// check if current token type is `t`
 	return p.curTok.Type == t
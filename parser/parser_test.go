@@ -0,0 +1,120 @@
+package parser
+
+import (
+	"testing"
+
+	"go.cs.palashbauri.in/pankti/ast"
+	"go.cs.palashbauri.in/pankti/lexer"
+)
+
+func TestSpans(t *testing.T) {
+	inp := "dhori যোগ = ekti kaj(ক, খ)\n  ফেরাও ক + খ\nsesh\ndekhau(যোগ(১, ২) * ৩)"
+
+	lx := lexer.NewLexer(inp)
+	p := NewParser(&lx)
+	prog := p.ParseProg()
+	if len(p.GetErrors()) > 0 {
+		t.Fatalf("unexpected parser errors %v", p.GetErrors())
+	}
+
+	let := prog.Stmts[0].(*ast.LetStmt)
+	fn := let.Value.(*ast.FunctionLit)
+	ret := fn.Body.Stmts[0].(*ast.ReturnStmt)
+	show := prog.Stmts[1].(*ast.ShowStmt)
+	mul := show.Value[0].(*ast.InfixExpr)
+
+	tests := []struct {
+		node         ast.Node
+		expectedText string
+	}{
+		{prog, inp},
+		{let, "dhori যোগ = ekti kaj(ক, খ)\n  ফেরাও ক + খ\nsesh"},
+		{&let.Name, "যোগ"},
+		{fn, "ekti kaj(ক, খ)\n  ফেরাও ক + খ\nsesh"},
+		{fn.Params[1], "খ"},
+		{fn.Body, "ফেরাও ক + খ\nsesh"},
+		{ret, "ফেরাও ক + খ"},
+		{ret.ReturnVal, "ক + খ"},
+		{show, "dekhau(যোগ(১, ২) * ৩)"},
+		{mul, "যোগ(১, ২) * ৩"},
+		{mul.Left, "যোগ(১, ২)"},
+		{mul.Left.(*ast.CallExpr).Args[1], "২"},
+	}
+
+	for i, tt := range tests {
+		sp := tt.node.Span()
+		text := inp[sp.Start.Offset:sp.End.Offset]
+		if text != tt.expectedText {
+			t.Errorf("tests[%d] -> span wrong -> Expected=%q, Got=%q", i, tt.expectedText, text)
+		}
+	}
+
+	if sp := mul.Span(); sp.Start.Line != 4 || sp.Start.Column != 8 || sp.End.Column != 21 {
+		t.Errorf("position wrong -> Got=%+v", sp)
+	}
+}
+
+func TestDocComments(t *testing.T) {
+	inp := `## দুটি সংখ্যা যোগ করে
+## ফেরত দেয়
+dhori যোগ = ekti kaj(ক, খ)
+	## ভিতরের মান
+	dhori ফল = ক + খ
+	ফেরাও ফল
+sesh
+## কিছুর আগে নয়
+যোগ(১, ২)`
+
+	lx := lexer.NewLexer(inp)
+	p := NewParser(&lx)
+	prog := p.ParseProg()
+	if len(p.GetErrors()) > 0 {
+		t.Fatalf("unexpected parser errors %v", p.GetErrors())
+	}
+
+	if len(prog.Stmts) != 3 {
+		t.Fatalf("expected 3 statements, got %d", len(prog.Stmts))
+	}
+
+	let := prog.Stmts[0].(*ast.LetStmt)
+	fn := let.Value.(*ast.FunctionLit)
+	if let.Doc != "দুটি সংখ্যা যোগ করে\nফেরত দেয়" || fn.Doc != let.Doc {
+		t.Errorf("wrong doc %q, %q", let.Doc, fn.Doc)
+	}
+
+	if inner := fn.Body.Stmts[0].(*ast.LetStmt); inner.Doc != "ভিতরের মান" {
+		t.Errorf("wrong doc %q", inner.Doc)
+	}
+
+	if c, ok := prog.Stmts[1].(*ast.Comment); !ok || c.Value != "কিছুর আগে নয়" {
+		t.Errorf("expected a comment, got %v", prog.Stmts[1])
+	}
+}
+
+func TestSliceExpr(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"a[1]", "(a[1])"},
+		{"a[1:3]", "(a[1:3])"},
+		{"a[:3]", "(a[:3])"},
+		{"a[1 + 1:]", "(a[(1 + 1):])"},
+		{"a[:]", "(a[:])"},
+		{"a[1:2][0]", "((a[1:2])[0])"},
+	}
+
+	for i, tt := range tests {
+		lx := lexer.NewLexer(tt.input)
+		p := NewParser(&lx)
+		prog := p.ParseProg()
+		if len(p.GetErrors()) > 0 {
+			t.Errorf("tests[%d] -> unexpected parser errors %v", i, p.GetErrors())
+			continue
+		}
+
+		if got := prog.String(); got != tt.expected {
+			t.Errorf("tests[%d] -> Expected=%q, Got=%q", i, tt.expected, got)
+		}
+	}
+}
@@ -1,12 +1,10 @@
 package parser
 
 import (
-	"math/big"
 	"strings"
 
-	log "github.com/sirupsen/logrus"
 	"go.cs.palashbauri.in/pankti/ast"
-	"go.cs.palashbauri.in/pankti/number"
+	"go.cs.palashbauri.in/pankti/errs"
 	"go.cs.palashbauri.in/pankti/token"
 )
 
@@ -15,6 +13,48 @@ func (p *Parser) parseStringLit() ast.Expr {
 	//fmt.Println(p.curTok)
 	return &ast.StringLit{Token: p.curTok, Value: p.curTok.Literal}
 }
+
+// parseConcatExpr parses a string with expressions in it, such as
+// "নাম: {নাম}", into the parts to be joined
+func (p *Parser) parseConcatExpr() ast.Expr {
+	exp := &ast.ConcatExpr{Token: p.curTok}
+	p.addStringPart(exp)
+
+	for !p.isCurToken(token.STRING_TAIL) {
+		p.nextToken()
+		part := p.parseExpr(LOWEST)
+		exp.Parts = append(exp.Parts, part)
+
+		// a quantifier of a regex such as "\d{3}" is likely meant, not
+		// the number itself
+		if n, ok := part.(*ast.NumberLit); ok {
+			p.warn(n.Token, errs.NUMBER_INTERPOLATED, n.Token.Literal, n.Token.Literal)
+		}
+
+		if !p.isPeekToken(token.STRING_MID) && !p.isPeekToken(token.STRING_TAIL) {
+			p.peekErr(token.RBRACE)
+			return nil
+		}
+
+		p.nextToken()
+		p.addStringPart(exp)
+	}
+
+	return exp
+}
+
+// addStringPart adds the text of the current part of a string to exp,
+// unless it is empty
+func (p *Parser) addStringPart(exp *ast.ConcatExpr) {
+	if len(p.curTok.Literal) == 0 {
+		return
+	}
+
+	s := &ast.StringLit{Token: p.curTok, Value: p.curTok.Literal}
+	s.SetSpan(p.curTok.Span)
+	exp.Parts = append(exp.Parts, s)
+}
+
 func (p *Parser) parseIdent() ast.Expr {
 	isModID := len(strings.Split(p.curTok.Literal, ".")) == 2
 	//fmt.Println(p.curTok.Literal , " -> " , isModID)
@@ -26,7 +66,7 @@ func (p *Parser) parseIdent() ast.Expr {
 	//	}
 	//}
 
-	log.Info("IDENT EXPR =>", p.curTok)
+	p.log.Info("IDENT EXPR =>", p.curTok)
 	return &ast.Identifier{
 		Token: p.curTok,
 		IsMod: isModID,
@@ -36,7 +76,7 @@ func (p *Parser) parseIdent() ast.Expr {
 }
 
 func (p *Parser) parseBool() ast.Expr {
-	log.Info("BOOL EXPR => ", p.curTok)
+	p.log.Info("BOOL EXPR => ", p.curTok)
 	return &ast.Boolean{
 		Token: p.curTok,
 		Value: p.isCurToken(token.TRUE),
@@ -54,21 +94,12 @@ func (p *Parser) parseNumLit() ast.Expr {
 
 	lit := &ast.NumberLit{Token: p.curTok}
 
-	if number.IsFloat(p.curTok.Literal) {
-		v, _ := new(big.Float).SetString(p.curTok.Literal)
-		lit.Value = number.Number{
-			Value: &number.FloatNumber{Value: *v},
-			IsInt: false,
-		}
-		lit.IsInt = false
-	} else {
-		v, _ := new(big.Int).SetString(p.curTok.Literal, 10)
-		lit.Value = number.Number{
-			Value: &number.IntNumber{Value: *v},
-			IsInt: true,
-		}
-		lit.IsInt = true
+	// the lexer has already turned the literal into ascii digits
+	if !lit.Value.SetValue(p.curTok.Literal) {
+		p.errs = append(p.errs, &errs.IntegerParseError{Msg: p.msg(errs.INT_PARSE_ERR), Token: p.curTok})
+		return nil
 	}
+	lit.IsInt = lit.Value.IsInt
 
 	//if err != nil{
 	//    return nil
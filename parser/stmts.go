@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	log "github.com/sirupsen/logrus"
 	"go.cs.palashbauri.in/pankti/ast"
 	"go.cs.palashbauri.in/pankti/token"
 )
@@ -19,7 +18,8 @@ func (p *Parser) parseShowStmt() *ast.ShowStmt {
 		p.nextToken()
 	}
 
-	log.Info(fmt.Sprintf("SHOW STMT => %v\n", stmt))
+	stmt.SetSpan(p.spanFrom(stmt.Token.Span.Start))
+	p.log.Info(fmt.Sprintf("SHOW STMT => %v\n", stmt))
 
 	return stmt
 }
@@ -29,7 +29,7 @@ func (p *Parser) parseIncludeExpr() ast.Expr {
 	p.nextToken()
 
 	exp.Filename = p.parseExpr(LOWEST)
-	log.Info(fmt.Sprintf(
+	p.log.Info(fmt.Sprintf(
 		"INCLUDE EXPR => FNAME->%s",
 		exp.Filename,
 	))
@@ -51,6 +51,7 @@ func (p *Parser) parseLetStmt() *ast.LetStmt {
 		IsMod: isModId,
 		Value: p.curTok.Literal,
 	}
+	stmt.Name.SetSpan(p.curTok.Span)
 
 	if !p.peek(token.EQ) {
 		return nil
@@ -66,7 +67,8 @@ func (p *Parser) parseLetStmt() *ast.LetStmt {
 		p.nextToken()
 	}
 
-	log.Info(fmt.Sprintf("LET STMT => %v\n", stmt))
+	stmt.SetSpan(p.spanFrom(stmt.Token.Span.Start))
+	p.log.Info(fmt.Sprintf("LET STMT => %v\n", stmt))
 	return stmt
 
 }
@@ -82,7 +84,8 @@ func (p *Parser) parseReturnStmt() *ast.ReturnStmt {
 		p.nextToken()
 	}
 
-	log.Info(fmt.Sprintf("RETURN STMT => %v\n", stmt))
+	stmt.SetSpan(p.spanFrom(stmt.Token.Span.Start))
+	p.log.Info(fmt.Sprintf("RETURN STMT => %v\n", stmt))
 
 	return stmt
 
@@ -94,6 +97,7 @@ func (p *Parser) parseBlockStmt(eT token.TokenType) *ast.BlockStmt {
 	//	bs.Stmts = []ast.Stmt{}
 
 	p.nextToken()
+	start := p.curTok.Span.Start
 
 	for !p.isCurToken(eT) && !p.isCurToken(token.EOF) {
 		s := p.parseStmt()
@@ -104,6 +108,9 @@ func (p *Parser) parseBlockStmt(eT token.TokenType) *ast.BlockStmt {
 	}
 	//fmt.Println("BS=> " , bs)
 
+	// the block with the token ending it
+	bs.SetSpan(p.spanFrom(start))
+
 	return bs
 }
 
@@ -116,6 +123,7 @@ func (p *Parser) parseExprStmt() *ast.ExprStmt {
 	if p.isPeekToken(token.SEMICOLON) {
 		p.nextToken()
 	}
+	stmt.SetSpan(p.spanFrom(stmt.Token.Span.Start))
 	//fmt.Println("expr stmt->>>" , stmt)
 	return stmt
 }
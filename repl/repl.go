@@ -2,15 +2,12 @@ package repl
 
 import (
 	"bufio"
-	"bytes"
+	"context"
 	"fmt"
 	"io"
 
 	"go.cs.palashbauri.in/pankti/errs"
-	"go.cs.palashbauri.in/pankti/evaluator"
-	"go.cs.palashbauri.in/pankti/lexer"
-	"go.cs.palashbauri.in/pankti/object"
-	"go.cs.palashbauri.in/pankti/parser"
+	"go.cs.palashbauri.in/pankti/pankti"
 )
 
 const PROMPT = "-> "
@@ -18,7 +15,7 @@ const PROMPT = "-> "
 // Deprecated
 func Repl(in io.Reader, out io.Writer) {
 	scanner := bufio.NewScanner(in)
-	env := object.NewEnvMap()
+	it := pankti.New(pankti.WithStdout(out), pankti.WithStderr(out))
 
 	for {
 		fmt.Fprint(out, PROMPT)
@@ -29,28 +26,9 @@ func Repl(in io.Reader, out io.Writer) {
 		}
 
 		input := scanner.Text()
-		rlexer := lexer.NewLexer(input)
-		//fmt.Println(rlexer.)
-		/*
-		   for !rlexer.AtEOF(){
-		       fmt.Println(rlexer.NextToken().Type)
-		   }
-		*/
-		p := parser.NewParser(&rlexer)
+		evals, err := it.Run(context.Background(), input)
 
-		prog := p.ParseProg()
-		fmt.Println(prog.Stmts)
-
-		if len(p.GetErrors()) != 0 {
-			ShowParseErrors(out, p.GetErrors())
-			continue
-		}
-		eh := object.ErrorHelper{Source: input}
-
-		printBuff := bytes.Buffer{}
-		evals := evaluator.Eval(prog, env, eh, &printBuff, false)
-
-		if evals != nil {
+		if err == nil && evals != nil {
 			//fmt.Println(evals)
 			io.WriteString(out, evals.Inspect())
 			io.WriteString(out, "\n")
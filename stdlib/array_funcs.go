@@ -0,0 +1,385 @@
+package stdlib
+
+import (
+	"sort"
+
+	"go.cs.palashbauri.in/pankti/collate"
+	"go.cs.palashbauri.in/pankti/number"
+	"go.cs.palashbauri.in/pankti/object"
+	"go.cs.palashbauri.in/pankti/token"
+)
+
+// The functions here call a function of the program for the elements of
+// an array, through the evaluator or the vm which runs it. Errors of the
+// function, and the limits of the runtime, stop them like any other call
+
+// arity returns how many parameters a function takes
+func arity(fn object.Obj) int {
+	switch fn := fn.(type) {
+	case *object.Function:
+		return len(fn.Params)
+	case *object.Closure:
+		return fn.Fn.NumParams
+	default:
+		return 1
+	}
+}
+
+// truthy is true for every value except false and nil, as in conditions
+func truthy(o object.Obj) bool {
+	switch o := o.(type) {
+	case *object.Boolean:
+		return o.Value
+	case *object.Null:
+		return false
+	default:
+		return true
+	}
+}
+
+// forEach calls the function given as args[1] with each element of the
+// array given as args[0], and also with its index if the function takes
+// two parameters. It stops when visit returns false or the function
+// returns an error, which it then returns
+func forEach(
+	eh *object.ErrorHelper,
+	env *object.EnvMap,
+	caller token.Token,
+	args []object.Obj,
+	visit func(el object.Obj, result object.Obj) bool,
+) object.Obj {
+	fn := args[1]
+	withIndex := arity(fn) == 2
+	rt := env.GetRuntime()
+
+	for i, el := range args[0].(*object.Array).Elms {
+		fnArgs := []object.Obj{el}
+		if withIndex {
+			fnArgs = append(fnArgs, object.MakeIntNumber(int64(i)))
+		}
+
+		result := rt.Call(eh, caller, fn, fnArgs)
+		if object.IsErr(result) {
+			return result
+		}
+
+		if !visit(el, result) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// ArrayMap returns the results of the function for each element
+func ArrayMap(eh *object.ErrorHelper, env *object.EnvMap, caller token.Token, args []object.Obj) object.Obj {
+	result := &object.Array{Elms: []object.Obj{}}
+	err := forEach(eh, env, caller, args, func(_, r object.Obj) bool {
+		result.Elms = append(result.Elms, r)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	return result
+}
+
+// ArrayFilter returns the elements for which the function is true
+func ArrayFilter(eh *object.ErrorHelper, env *object.EnvMap, caller token.Token, args []object.Obj) object.Obj {
+	result := &object.Array{Elms: []object.Obj{}}
+	err := forEach(eh, env, caller, args, func(el, r object.Obj) bool {
+		if truthy(r) {
+			result.Elms = append(result.Elms, el)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	return result
+}
+
+// ArrayFind returns the first element for which the function is true,
+// or nil if there is none
+func ArrayFind(eh *object.ErrorHelper, env *object.EnvMap, caller token.Token, args []object.Obj) object.Obj {
+	var found object.Obj = &object.Null{}
+	err := forEach(eh, env, caller, args, func(el, r object.Obj) bool {
+		if truthy(r) {
+			found = el
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	return found
+}
+
+// ArrayAny tells if the function is true for any of the elements
+func ArrayAny(eh *object.ErrorHelper, env *object.EnvMap, caller token.Token, args []object.Obj) object.Obj {
+	found := false
+	err := forEach(eh, env, caller, args, func(_, r object.Obj) bool {
+		found = truthy(r)
+		return !found
+	})
+	if err != nil {
+		return err
+	}
+
+	return &object.Boolean{Value: found}
+}
+
+// ArrayAll tells if the function is true for all of the elements
+func ArrayAll(eh *object.ErrorHelper, env *object.EnvMap, caller token.Token, args []object.Obj) object.Obj {
+	every := true
+	err := forEach(eh, env, caller, args, func(_, r object.Obj) bool {
+		every = truthy(r)
+		return every
+	})
+	if err != nil {
+		return err
+	}
+
+	return &object.Boolean{Value: every}
+}
+
+// ArrayReduce combines the elements from the first to the last, starting
+// with the initial value: the function is called with the value so far
+// and an element (and its index if it takes three parameters) and
+// returns the next value
+func ArrayReduce(eh *object.ErrorHelper, env *object.EnvMap, caller token.Token, args []object.Obj) object.Obj {
+	fn := args[1]
+	withIndex := arity(fn) == 3
+	rt := env.GetRuntime()
+	acc := args[2]
+
+	for i, el := range args[0].(*object.Array).Elms {
+		fnArgs := []object.Obj{acc, el}
+		if withIndex {
+			fnArgs = append(fnArgs, object.MakeIntNumber(int64(i)))
+		}
+
+		acc = rt.Call(eh, caller, fn, fnArgs)
+		if object.IsErr(acc) {
+			return acc
+		}
+	}
+
+	return acc
+}
+
+// sortOptions are the options of ArraySort, given as a hash
+type sortOptions struct {
+	reverse bool
+	stable  bool
+	// called with each element, to sort by its result instead
+	key object.Obj
+	// called with two elements, to tell which comes first
+	compare object.Obj
+}
+
+// sortOptionNames maps the bengali and english names of the options to
+// the english ones
+var sortOptionNames = map[string]string{
+	"উল্টো":   "reverse",
+	"reverse": "reverse",
+	"স্থির":   "stable",
+	"stable":  "stable",
+	"চাবি":    "key",
+	"key":     "key",
+	"তুলনা":   "compare",
+	"compare": "compare",
+}
+
+func parseSortOptions(eh *object.ErrorHelper, caller token.Token, args []object.Obj) (*sortOptions, object.Obj) {
+	opts := &sortOptions{stable: true}
+
+	for _, a := range args {
+		h, ok := a.(*object.Hash)
+		if !ok {
+			opts.compare = a
+			continue
+		}
+
+		for _, p := range h.Pairs {
+			name := sortOptionNames[token.Normalize(p.Key.Inspect())]
+			b, isBool := p.Value.(*object.Boolean)
+			isFunc := isFunction(p.Value)
+
+			switch {
+			case name == "reverse" && isBool:
+				opts.reverse = b.Value
+			case name == "stable" && isBool:
+				opts.stable = b.Value
+			case name == "key" && isFunc:
+				opts.key = p.Value
+			case name == "compare" && isFunc:
+				opts.compare = p.Value
+			default:
+				return nil, object.NewErr(caller, eh, true, eh.Msg("SORT_BAD_OPTION"), p.Key.Inspect())
+			}
+		}
+	}
+
+	return opts, nil
+}
+
+func isFunction(o object.Obj) bool {
+	for _, t := range function {
+		if o.Type() == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ArraySort returns the elements in order. Numbers, including strings of
+// ascii or bengali digits, come first in increasing order and then the
+// strings in the order of a bengali dictionary. It can be given a
+// function, which is called with two elements and returns true, or a
+// negative number, if the first comes before the second, or a hash of
+// options:
+//
+//	উল্টো/reverse  the opposite order
+//	চাবি/key       a function whose results for the elements are sorted
+//	               instead of the elements
+//	তুলনা/compare  the function which compares two elements
+//	স্থির/stable   equal elements keep their order; true unless it is
+//	               set to false
+func ArraySort(eh *object.ErrorHelper, env *object.EnvMap, caller token.Token, args []object.Obj) object.Obj {
+	opts, err := parseSortOptions(eh, caller, args[1:])
+	if err != nil {
+		return err
+	}
+
+	rt := env.GetRuntime()
+	elms := args[0].(*object.Array).Elms
+
+	keys := elms
+	if opts.key != nil {
+		keys = make([]object.Obj, len(elms))
+		for i, el := range elms {
+			keys[i] = rt.Call(eh, caller, opts.key, []object.Obj{el})
+			if object.IsErr(keys[i]) {
+				return keys[i]
+			}
+		}
+	}
+
+	var failed object.Obj
+	compare := func(a, b object.Obj) int {
+		c, err := compareValues(eh, caller, a, b)
+		if err != nil {
+			failed = err
+		}
+		return c
+	}
+
+	if opts.compare != nil {
+		compare = func(a, b object.Obj) int {
+			r := rt.Call(eh, caller, opts.compare, []object.Obj{a, b})
+			switch r := r.(type) {
+			case *object.Boolean:
+				if r.Value {
+					return -1
+				}
+				return 0
+			case *object.Number:
+				return number.Compare(r.Value, number.MakeInt(0))
+			case *object.Error:
+				failed = r
+			default:
+				failed = object.NewErr(caller, eh, true, eh.Msg("SORT_BAD_COMPARATOR"), eh.TypeName(r.Type()))
+			}
+			return 0
+		}
+	}
+
+	order := make([]int, len(elms))
+	for i := range order {
+		order[i] = i
+	}
+
+	less := func(i, j int) bool {
+		if failed != nil {
+			return false
+		}
+
+		a, b := keys[order[i]], keys[order[j]]
+		if opts.reverse {
+			a, b = b, a
+		}
+		return compare(a, b) < 0
+	}
+
+	if opts.stable {
+		sort.SliceStable(order, less)
+	} else {
+		sort.Slice(order, less)
+	}
+
+	if failed != nil {
+		return failed
+	}
+
+	result := &object.Array{Elms: make([]object.Obj, len(elms))}
+	for i, o := range order {
+		result.Elms[i] = elms[o]
+	}
+
+	return result
+}
+
+// CompareValues compares two numbers or strings like ArraySort, and
+// returns -1, 0 or 1 as the first comes before, is equal to or comes
+// after the second
+func CompareValues(eh *object.ErrorHelper, _ *object.EnvMap, caller token.Token, args []object.Obj) object.Obj {
+	c, err := compareValues(eh, caller, args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	return object.MakeIntNumber(int64(c))
+}
+
+// compareValues compares two numbers or two strings, and puts numbers,
+// and strings which are numbers, before other strings
+func compareValues(eh *object.ErrorHelper, caller token.Token, a, b object.Obj) (int, object.Obj) {
+	for _, o := range []object.Obj{a, b} {
+		if o.Type() != object.NUM_OBJ && o.Type() != object.STRING_OBJ {
+			return 0, object.NewErr(caller, eh, true, eh.Msg("SORT_NOT_COMPARABLE"), eh.TypeName(o.Type()))
+		}
+	}
+
+	na, aNum := numericValue(a)
+	nb, bNum := numericValue(b)
+
+	switch {
+	case aNum && bNum:
+		if c := number.Compare(na.Value, nb.Value); c != 0 {
+			return c, nil
+		}
+	case aNum:
+		return -1, nil
+	case bNum:
+		return 1, nil
+	}
+
+	return collate.Compare(a.Inspect(), b.Inspect()), nil
+}
+
+func numericValue(o object.Obj) (*object.Number, bool) {
+	switch o := o.(type) {
+	case *object.Number:
+		return o, true
+	case *object.String:
+		return numberLike(o.Value)
+	}
+
+	return nil, false
+}
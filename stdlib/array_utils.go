@@ -1,24 +1,19 @@
 package stdlib
 
 import (
-	"go.cs.palashbauri.in/pankti/errs"
 	"go.cs.palashbauri.in/pankti/number"
 	"go.cs.palashbauri.in/pankti/object"
 )
 
-func getArrayFromArg(arg object.Obj) ([]object.Obj, bool) {
-	if arg.Type() != object.ARRAY_OBJ {
-		return []object.Obj{}, false
-	}
-	xa := arg.(*object.Array).Elms
-	return xa, true
+// arrayArg returns the elements of an array argument, which the signature
+// of the builtin has already checked
+func arrayArg(args []object.Obj, i int) []object.Obj {
+	return args[i].(*object.Array).Elms
 }
 
+// getIntFromArg returns the value of a number argument, or false if it is
+// not a whole number
 func getIntFromArg(arg object.Obj) (int64, bool) {
-	if arg.Type() != object.NUM_OBJ {
-		return 0, false
-	}
-
 	xa := arg.(*object.Number).Value
 
 	if xa.IsInt {
@@ -36,119 +31,138 @@ func getIntFromArg(arg object.Obj) (int64, bool) {
 	}
 }
 
-func ArrayPopWithoutIndex(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	elms, ok := getArrayFromArg(args[0])
+// indexArg returns the index given as args[i] into a list of n elements,
+// or an error if it is not a whole number or is outside of the list
+func indexArg(eh *object.ErrorHelper, args []object.Obj, i int, n int) (int, object.Obj) {
+	index, ok := getIntFromArg(args[i])
 
 	if !ok {
-		object.NewErr(args[0].GetToken(), eh, true, errs.Errs["NOT_ALL_ARE_LIST"])
+		return 0, object.NewErr(args[i].GetToken(), eh, true, eh.Msg("INDEX_MUST_BE_NUMBER"))
+	}
+	if index < 0 {
+		return 0, object.NewErr(args[i].GetToken(), eh, true, eh.Msg("INDEX_NEGATIVE"))
+	}
+	if index >= int64(n) {
+		return 0, object.NewErr(args[i].GetToken(), eh, true, eh.Msg("INDEX_OUT_RANGE"))
 	}
 
-	return &object.Array{Elms: elms[:len(elms)-1]}
+	return int(index), nil
 }
 
-func ArrayPopIndex(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	elms, ok := getArrayFromArg(args[0])
-	if !ok {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["NOT_ALL_ARE_LIST"])
+func ArrayPopWithoutIndex(eh *object.ErrorHelper, args []object.Obj) object.Obj {
+	elms := arrayArg(args, 0)
+
+	if len(elms) == 0 {
+		return object.NewErr(args[0].GetToken(), eh, true, eh.Msg("EMPTY_LIST"))
 	}
 
-	i, ok2 := getIntFromArg(args[1])
+	newElms := make([]object.Obj, len(elms)-1)
+	copy(newElms, elms)
+	return &object.Array{Elms: newElms}
+}
 
-	if ok2 {
-		return object.NewErr(args[1].GetToken(), eh, true, errs.Errs["INDEX_MUST_BE_NUMBER"])
-	}
-	if i >= int64(len(elms)) {
-		return object.NewErr(args[1].GetToken(), eh, true, errs.Errs["INDEX_OUT_RANGE"])
+func ArrayPopIndex(eh *object.ErrorHelper, args []object.Obj) object.Obj {
+	elms := arrayArg(args, 0)
+
+	i, err := indexArg(eh, args, 1, len(elms))
+	if err != nil {
+		return err
 	}
-	s := append(elms[:i], elms[i+1:]...)
+
+	// a new array, since the one given may still be used by the program
+	newElms := make([]object.Obj, 0, len(elms)-1)
+	newElms = append(newElms, elms[:i]...)
+	newElms = append(newElms, elms[i+1:]...)
 
 	return &object.Array{
-		Elms: s,
+		Elms: newElms,
 	}
 
 }
 
 func JoinArrays(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	x_elms, ok := getArrayFromArg(args[0])
-	if !ok {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["NOT_ALL_ARE_LIST"])
-	}
-
-	y_elms, ok2 := getArrayFromArg(args[2])
+	x_elms := arrayArg(args, 0)
+	y_elms := arrayArg(args, 1)
 
-	if !ok2 {
+	result := make([]object.Obj, 0, len(x_elms)+len(y_elms))
+	result = append(result, x_elms...)
+	result = append(result, y_elms...)
 
-		return object.NewErr(args[1].GetToken(), eh, true, errs.Errs["NOT_ALL_ARE_LIST"])
-	}
+	return &object.Array{Elms: result}
+}
 
-	x_elms = append(x_elms, y_elms...)
+// putAt returns a copy of elms with the element at index replaced by
+// the values
+func putAt(elms []object.Obj, index int, values ...object.Obj) *object.Array {
+	result := make([]object.Obj, 0, len(elms)+len(values)-1)
+	result = append(result, elms[:index]...)
+	result = append(result, values...)
+	result = append(result, elms[index+1:]...)
 
-	return &object.Array{Elms: x_elms}
+	return &object.Array{Elms: result}
 }
 
 func InsertToArray(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	x_elms, ok := getArrayFromArg(args[0])
+	x_elms := arrayArg(args, 0)
+	y := args[1]
 
-	if !ok {
+	index, err := indexArg(eh, args, 2, len(x_elms))
+	if err != nil {
+		return err
+	}
 
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["NOT_ALL_ARE_LIST"])
+	if y, ok := y.(*object.Array); ok {
+		return putAt(x_elms, index, y.Elms...)
 	}
 
-	y := args[1]
-	index, ok2 := getIntFromArg(args[2])
+	return putAt(x_elms, index, y)
+}
 
-	if !ok2 {
+func InsertToArrayAsIs(eh *object.ErrorHelper, args []object.Obj) object.Obj {
+	x_elms := arrayArg(args, 0)
 
-		return object.NewErr(args[2].GetToken(), eh, true, errs.Errs["INDEX_MUST_BE_NUMBER"])
-	}
-	if index >= int64(len(x_elms)) {
-		return object.NewErr(y.GetToken(), eh, true, errs.Errs["INDEX_OUT_RANGE"])
+	index, err := indexArg(eh, args, 2, len(x_elms))
+	if err != nil {
+		return err
 	}
-	if y.Type() == object.ARRAY_OBJ {
-		y_elms, oky := getArrayFromArg(y)
 
-		if !oky {
-
-			return object.NewErr(y.GetToken(), eh, true, errs.Errs["NOT_ALL_ARE_LIST"])
-		}
-
-		result := append(x_elms[:index], append(y_elms, x_elms[index+1:]...)...)
+	return putAt(x_elms, index, args[1])
+}
 
-		return &object.Array{
-			Elms: result,
-		}
+func ArrayFirst(eh *object.ErrorHelper, args []object.Obj) object.Obj {
+	elms := arrayArg(args, 0)
 
-	} else {
-		result := x_elms[:index]
-		result = append(result, y)
-		result = append(result, x_elms[index+1:]...)
-		return &object.Array{Elms: result}
+	if len(elms) > 0 {
+		return elms[0]
 	}
-
+	return &object.Null{}
 }
 
-func InsertToArrayAsIs(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	x_elms, ok := getArrayFromArg(args[0])
-
-	if !ok {
+func ArrayLast(eh *object.ErrorHelper, args []object.Obj) object.Obj {
+	elms := arrayArg(args, 0)
 
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["NOT_ALL_ARE_LIST"])
+	if len(elms) > 0 {
+		return elms[len(elms)-1]
 	}
+	return &object.Null{}
+}
 
-	y := args[1]
-	index, ok2 := getIntFromArg(args[2])
-
-	if !ok2 {
+func ArrayRest(eh *object.ErrorHelper, args []object.Obj) object.Obj {
+	elms := arrayArg(args, 0)
 
-		return object.NewErr(args[2].GetToken(), eh, true, errs.Errs["INDEX_MUST_BE_NUMBER"])
+	if len(elms) > 0 {
+		newElms := make([]object.Obj, len(elms)-1)
+		copy(newElms, elms[1:])
+		return &object.Array{Elms: newElms}
 	}
+	return &object.Null{}
+}
 
-	if index >= int64(len(x_elms)) {
-		return object.NewErr(y.GetToken(), eh, true, errs.Errs["INDEX_OUT_RANGE"])
-	}
+func ArrayPush(eh *object.ErrorHelper, args []object.Obj) object.Obj {
+	elms := arrayArg(args, 0)
 
-	result := x_elms[:index]
-	result = append(result, y)
-	result = append(result, x_elms[index+1:]...)
-	return &object.Array{Elms: result}
+	newElms := make([]object.Obj, len(elms)+1)
+	copy(newElms, elms)
+	newElms[len(elms)] = args[1]
+	return &object.Array{Elms: newElms}
 }
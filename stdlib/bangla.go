@@ -0,0 +1,79 @@
+package stdlib
+
+import (
+	"go.cs.palashbauri.in/pankti/bangla"
+	"go.cs.palashbauri.in/pankti/object"
+)
+
+// The bangla module wraps the bangla package, whose aksharas are the same
+// grapheme clusters that len, indexing and reverse count in strings
+
+// stringsArray makes an array of strings
+func stringsArray(ss []string) object.Obj {
+	result := &object.Array{Elms: make([]object.Obj, len(ss))}
+	for i, s := range ss {
+		result.Elms[i] = &object.String{Value: s}
+	}
+
+	return result
+}
+
+// Aksharas splits a string into its aksharas, such as ক্ষ and মা for
+// "ক্ষমা", leaving out white space
+func Aksharas(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return stringsArray(bangla.Aksharas(stringArg(args, 0)))
+}
+
+// Conjuncts returns the aksharas of a string which have a conjunct
+func Conjuncts(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return stringsArray(bangla.Conjuncts(stringArg(args, 0)))
+}
+
+func IsConjunct(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return &object.Boolean{Value: bangla.IsConjunct(stringArg(args, 0))}
+}
+
+func HasHasanta(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return &object.Boolean{Value: bangla.HasHasanta(stringArg(args, 0))}
+}
+
+// VowelSigns returns the vowel signs (কার) of a string in order
+func VowelSigns(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return stringsArray(bangla.VowelSigns(stringArg(args, 0)))
+}
+
+func StripVowelSigns(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return &object.String{Value: bangla.StripVowelSigns(stringArg(args, 0))}
+}
+
+// scriptNames are the names ScriptOf returns for the scripts
+var scriptNames = map[bangla.Script]string{
+	bangla.Bengali: "বাংলা",
+	bangla.Latin:   "লাতিন",
+	bangla.Mixed:   "মিশ্র",
+	bangla.Other:   "অন্য",
+}
+
+// ScriptOf tells if the letters of a string are বাংলা, লাতিন or মিশ্র
+// (both), or অন্য if it has no letters of either
+func ScriptOf(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return &object.String{Value: scriptNames[bangla.ScriptOf(stringArg(args, 0))]}
+}
+
+func Words(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return stringsArray(bangla.Words(stringArg(args, 0)))
+}
+
+// Sentences splits a string into sentences, which end with the dari ।,
+// ?, ! or a full stop
+func Sentences(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return stringsArray(bangla.Sentences(stringArg(args, 0)))
+}
+
+func WordCount(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return object.MakeIntNumber(int64(len(bangla.Words(stringArg(args, 0)))))
+}
+
+func SentenceCount(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return object.MakeIntNumber(int64(len(bangla.Sentences(stringArg(args, 0)))))
+}
@@ -1,47 +1,90 @@
 package stdlib
 
 import (
-	"bufio"
 	"fmt"
-	"os"
+	"strings"
+	"unicode/utf8"
 
-	"go.cs.palashbauri.in/pankti/constants"
-	"go.cs.palashbauri.in/pankti/errs"
+	"go.cs.palashbauri.in/pankti/grapheme"
 	"go.cs.palashbauri.in/pankti/object"
 )
 
 func ReturnErrorString(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	msg, ok := getStringFromArgs(args[0])
+	return object.NewBareErr(stringArg(args, 0))
+}
+
+func ReadLine(eh *object.ErrorHelper, rt *object.Runtime, args []object.Obj) object.Obj {
+	text, err := rt.ReadLine(stringArg(args, 0))
 
-	if !ok {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["NOT_ALL_STRING"])
+	if err != nil {
+		return object.NewErr(args[0].GetToken(), eh, false, eh.Msg("STDIN_READ_FAILED"))
 	}
 
-	return object.NewBareErr(msg)
+	return &object.String{Value: text}
 }
 
-func ReadLine(eh *object.ErrorHelper, args []object.Obj) object.Obj {
+func GetType(eh *object.ErrorHelper, args []object.Obj) object.Obj {
+	return &object.String{Value: eh.TypeName(args[0].Type())}
+}
 
-	msg, ok := getStringFromArgs(args[0])
-	if !ok {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["NOT_ALL_STRING"])
+// Help returns the signature of a function, followed by the `##` comment
+// lines written before its definition
+func Help(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	var name, doc string
+	var params []string
+
+	switch fn := args[0].(type) {
+	case *object.Function:
+		name, doc = fn.Name, fn.Doc
+		for _, p := range fn.Params {
+			params = append(params, p.Value)
+		}
+	case *object.Closure:
+		name, params, doc = fn.Fn.Name, fn.Fn.Params, fn.Fn.Doc
+	case *object.Builtin:
+		if fn.Sig != nil {
+			return &object.String{Value: fn.Sig.String()}
+		}
 	}
 
-	reader := bufio.NewReader(os.Stdin)
-	fmt.Print(msg)
-	text, err := reader.ReadString('\n')
+	if len(name) == 0 {
+		name = "kaj"
+	}
 
-	if err != nil {
-		return object.NewErr(args[0].GetToken(), eh, false, errs.Errs["STDIN_READ_FAILED"])
+	help := name + "(" + strings.Join(params, ", ") + ")"
+	if len(doc) > 0 {
+		help += "\n" + doc
 	}
 
-	return &object.String{Value: text}
+	return &object.String{Value: help}
 }
 
-func GetType(_ *object.ErrorHelper, args []object.Obj) object.Obj {
-	t, ok := constants.TypeNames[string(args[0].Type())]
-	if !ok {
-		t = constants.UNKNOWN
+// Length returns the number of elements of an array, or the number of
+// grapheme clusters (the letters a reader sees) of a string
+func Length(eh *object.ErrorHelper, args []object.Obj) object.Obj {
+	if arg, ok := args[0].(*object.String); ok {
+		return object.MakeIntNumber(int64(grapheme.Count(arg.Value)))
 	}
-	return &object.String{Value: t}
+
+	return object.MakeIntNumber(int64(len(arrayArg(args, 0))))
+}
+
+// RuneLength returns the number of unicode code points of a string
+func RuneLength(eh *object.ErrorHelper, args []object.Obj) object.Obj {
+	return object.MakeIntNumber(int64(utf8.RuneCountInString(args[0].(*object.String).Value)))
+}
+
+// ByteLength returns the number of bytes of a string in UTF-8
+func ByteLength(eh *object.ErrorHelper, args []object.Obj) object.Obj {
+	return object.MakeIntNumber(int64(len(args[0].(*object.String).Value)))
+}
+
+func Show(env *object.EnvMap, args []object.Obj) object.Obj {
+	output := []string{}
+	for _, arg := range args {
+		output = append(output, env.GetRuntime().Format(arg))
+	}
+
+	fmt.Fprintln(env.GetRuntime().Stdout, strings.Join(output, ""))
+	return &object.Null{}
 }
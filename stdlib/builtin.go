@@ -27,10 +27,6 @@ func GetObjFromHashKey(i object.HashKey) (object.HashKey , bool){
 */
 
 func SetHashTableElm(eh *object.ErrorHelper, env *object.EnvMap, t token.Token, args []object.Obj) object.Obj {
-	if len(args) != 3 {
-		return object.NewErr(t, eh, true, "setting hash table value required three arguments")
-	}
-
 	rawHashTble := args[0]
 	hashKey := args[1]
 	newValue := args[2]
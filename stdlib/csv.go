@@ -0,0 +1,331 @@
+package stdlib
+
+import (
+	"bytes"
+	"encoding/csv"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"go.cs.palashbauri.in/pankti/object"
+	"go.cs.palashbauri.in/pankti/token"
+)
+
+// csvOptions are the options which the functions of the csv module take
+// as a hash after their other arguments
+type csvOptions struct {
+	// the first row names the columns, and the rows are hashes
+	header bool
+	// cells which look like numbers, in either digits, become numbers
+	numbers bool
+	// quotes may appear in unquoted cells when reading
+	lazyQuotes bool
+	// every cell is quoted when writing
+	quoteAll  bool
+	delimiter rune
+	// the order of the columns when writing hashes
+	columns []string
+}
+
+// csvOptionNames maps the bengali and english names of the options to
+// the english ones
+var csvOptionNames = map[string]string{
+	"শিরোনাম":      "header",
+	"header":       "header",
+	"সংখ্যা":       "numbers",
+	"numbers":      "numbers",
+	"ঢিলা_উদ্ধৃতি": "lazy_quotes",
+	"lazy_quotes":  "lazy_quotes",
+	"সব_উদ্ধৃতি":   "quote_all",
+	"quote_all":    "quote_all",
+	"বিভাজক":       "delimiter",
+	"delimiter":    "delimiter",
+	"কলাম":         "columns",
+	"columns":      "columns",
+}
+
+func parseCSVOptions(eh *object.ErrorHelper, caller token.Token, hashes []object.Obj) (*csvOptions, object.Obj) {
+	opts := &csvOptions{delimiter: ','}
+
+	for _, h := range hashes {
+		for _, p := range h.(*object.Hash).Pairs {
+			name := csvOptionNames[token.Normalize(p.Key.Inspect())]
+			bad := func() object.Obj {
+				return object.NewErr(caller, eh, true, eh.Msg("CSV_BAD_OPTION"), p.Key.Inspect())
+			}
+
+			switch name {
+			case "header", "numbers", "lazy_quotes", "quote_all":
+				b, ok := p.Value.(*object.Boolean)
+				if !ok {
+					return nil, bad()
+				}
+
+				switch name {
+				case "header":
+					opts.header = b.Value
+				case "numbers":
+					opts.numbers = b.Value
+				case "lazy_quotes":
+					opts.lazyQuotes = b.Value
+				case "quote_all":
+					opts.quoteAll = b.Value
+				}
+			case "delimiter":
+				s, ok := p.Value.(*object.String)
+				if !ok || utf8.RuneCountInString(s.Value) != 1 {
+					return nil, bad()
+				}
+
+				opts.delimiter, _ = utf8.DecodeRuneInString(s.Value)
+			case "columns":
+				a, ok := p.Value.(*object.Array)
+				if !ok {
+					return nil, bad()
+				}
+
+				for _, c := range a.Elms {
+					opts.columns = append(opts.columns, c.Inspect())
+				}
+			default:
+				return nil, bad()
+			}
+		}
+	}
+
+	return opts, nil
+}
+
+// ReadCSV reads a CSV text into an array of rows, each an array of
+// cells, or with the `header` option an array of hashes of the names of
+// the columns to the cells
+func ReadCSV(eh *object.ErrorHelper, _ *object.EnvMap, caller token.Token, args []object.Obj) object.Obj {
+	opts, err := parseCSVOptions(eh, caller, args[1:])
+	if err != nil {
+		return err
+	}
+
+	return decodeCSV(eh, caller, stringArg(args, 0), opts)
+}
+
+// ReadCSVFile is like ReadCSV but reads the file at a path
+func ReadCSVFile(eh *object.ErrorHelper, env *object.EnvMap, caller token.Token, args []object.Obj) object.Obj {
+	opts, err := parseCSVOptions(eh, caller, args[1:])
+	if err != nil {
+		return err
+	}
+
+	data, err := readFileArg(eh, env.GetRuntime(), args[0])
+	if err != nil {
+		return err
+	}
+
+	return decodeCSV(eh, caller, data, opts)
+}
+
+func decodeCSV(eh *object.ErrorHelper, caller token.Token, data string, opts *csvOptions) object.Obj {
+	r := csv.NewReader(strings.NewReader(data))
+	r.Comma = opts.delimiter
+	r.LazyQuotes = opts.lazyQuotes
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return object.NewErr(caller, eh, true, eh.Msg("CSV_READ_FAILED"), err.Error())
+	}
+
+	result := &object.Array{Elms: []object.Obj{}}
+	if !opts.header {
+		for _, record := range records {
+			row := &object.Array{Elms: []object.Obj{}}
+			for _, cell := range record {
+				row.Elms = append(row.Elms, csvCell(cell, opts.numbers))
+			}
+
+			result.Elms = append(result.Elms, row)
+		}
+
+		return result
+	}
+
+	if len(records) == 0 {
+		return result
+	}
+
+	for _, record := range records[1:] {
+		row := &object.Hash{Pairs: map[object.HashKey]object.HashPair{}}
+		for i, cell := range record {
+			key := &object.String{Value: records[0][i]}
+			row.Pairs[key.HashKey()] = object.HashPair{Key: key, Value: csvCell(cell, opts.numbers)}
+		}
+
+		result.Elms = append(result.Elms, row)
+	}
+
+	return result
+}
+
+// csvCell makes the value of a cell, which is a number if it looks like
+// one and numbers are wanted
+func csvCell(cell string, numbers bool) object.Obj {
+	if numbers {
+		if n, ok := numberLike(cell); ok {
+			return n
+		}
+	}
+
+	return &object.String{Value: cell}
+}
+
+// WriteCSV writes rows, which are either all arrays or all hashes, as a
+// CSV text. For hashes a header row is written first, with the columns
+// in the order of the `columns` option or else sorted by name
+func WriteCSV(eh *object.ErrorHelper, _ *object.EnvMap, caller token.Token, args []object.Obj) object.Obj {
+	opts, err := parseCSVOptions(eh, caller, args[1:])
+	if err != nil {
+		return err
+	}
+
+	data, err := encodeCSV(eh, caller, args[0].(*object.Array), opts)
+	if err != nil {
+		return err
+	}
+
+	return &object.String{Value: data}
+}
+
+// WriteCSVFile is like WriteCSV but writes the text to the file at a path
+func WriteCSVFile(eh *object.ErrorHelper, env *object.EnvMap, caller token.Token, args []object.Obj) object.Obj {
+	opts, err := parseCSVOptions(eh, caller, args[2:])
+	if err != nil {
+		return err
+	}
+
+	data, err := encodeCSV(eh, caller, args[1].(*object.Array), opts)
+	if err != nil {
+		return err
+	}
+
+	return writeFileArg(eh, env.GetRuntime(), args[0], data)
+}
+
+func encodeCSV(eh *object.ErrorHelper, caller token.Token, rows *object.Array, opts *csvOptions) (string, object.Obj) {
+	records := [][]string{}
+
+	hashes := len(rows.Elms) > 0 && rows.Elms[0].Type() == object.HASH_OBJ
+	columns := opts.columns
+	if hashes && len(columns) == 0 {
+		columns = csvColumns(rows)
+	}
+	if hashes {
+		records = append(records, columns)
+	}
+
+	for _, row := range rows.Elms {
+		record := []string{}
+
+		switch row := row.(type) {
+		case *object.Array:
+			if hashes {
+				return "", object.NewErr(caller, eh, true, eh.Msg("CSV_BAD_ROWS"))
+			}
+
+			for _, cell := range row.Elms {
+				s, bad := csvText(cell)
+				if bad != nil {
+					return "", object.NewErr(caller, eh, true, eh.Msg("CSV_BAD_CELL"), eh.TypeName(bad.Type()))
+				}
+
+				record = append(record, s)
+			}
+		case *object.Hash:
+			if !hashes {
+				return "", object.NewErr(caller, eh, true, eh.Msg("CSV_BAD_ROWS"))
+			}
+
+			for _, c := range columns {
+				key := &object.String{Value: c}
+				cell, ok := row.Pairs[key.HashKey()]
+				if !ok {
+					record = append(record, "")
+					continue
+				}
+
+				s, bad := csvText(cell.Value)
+				if bad != nil {
+					return "", object.NewErr(caller, eh, true, eh.Msg("CSV_BAD_CELL"), eh.TypeName(bad.Type()))
+				}
+
+				record = append(record, s)
+			}
+		default:
+			return "", object.NewErr(caller, eh, true, eh.Msg("CSV_BAD_ROWS"))
+		}
+
+		records = append(records, record)
+	}
+
+	if opts.quoteAll {
+		return quotedCSV(records, opts.delimiter), nil
+	}
+
+	var out bytes.Buffer
+	w := csv.NewWriter(&out)
+	w.Comma = opts.delimiter
+	if err := w.WriteAll(records); err != nil {
+		return "", object.NewErr(caller, eh, true, eh.Msg("CSV_BAD_OPTION"), string(opts.delimiter))
+	}
+
+	return out.String(), nil
+}
+
+// csvColumns returns the names of all the keys of the hashes among rows,
+// sorted
+func csvColumns(rows *object.Array) []string {
+	seen := map[string]bool{}
+	columns := []string{}
+
+	for _, row := range rows.Elms {
+		if h, ok := row.(*object.Hash); ok {
+			for _, p := range h.Pairs {
+				name := p.Key.Inspect()
+				if !seen[name] {
+					seen[name] = true
+					columns = append(columns, name)
+				}
+			}
+		}
+	}
+
+	sort.Strings(columns)
+	return columns
+}
+
+// csvText returns the text of a cell; it returns the value itself if it
+// can not be a cell
+func csvText(o object.Obj) (string, object.Obj) {
+	switch o := o.(type) {
+	case *object.Null:
+		return "", nil
+	case *object.String, *object.Number, *object.Boolean:
+		return o.Inspect(), nil
+	default:
+		return "", o
+	}
+}
+
+// quotedCSV writes records with every cell in quotes
+func quotedCSV(records [][]string, delimiter rune) string {
+	var out strings.Builder
+	for _, record := range records {
+		for i, cell := range record {
+			if i > 0 {
+				out.WriteRune(delimiter)
+			}
+
+			out.WriteString(`"` + strings.ReplaceAll(cell, `"`, `""`) + `"`)
+		}
+		out.WriteString("\n")
+	}
+
+	return out.String()
+}
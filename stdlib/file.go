@@ -1,173 +1,192 @@
 package stdlib
 
 import (
+	"errors"
 	"io/fs"
-	"os"
-	"path/filepath"
 
-	"go.cs.palashbauri.in/pankti/errs"
 	"go.cs.palashbauri.in/pankti/object"
+	"go.cs.palashbauri.in/pankti/vfs"
 )
 
-func getStringFromArgs(arg object.Obj) (string, bool) { // Value, isOkay
-	fileArg := arg
+// osFSOnAndroid reports if a program would use the filesystem of Android,
+// which is not supported
+func osFSOnAndroid(rt *object.Runtime) bool {
+	return vfs.IsOS(rt.FileSystem()) && IsAndroid()
+}
 
-	if fileArg.Type() == object.STRING_OBJ {
-		return fileArg.(*object.String).Value, true
+// fsErr returns the message of an error of the filesystem, with the path
+// in it written as the program knows it
+func fsErr(rt *object.Runtime, err error) string {
+	var perr *fs.PathError
+	if errors.As(err, &perr) {
+		e := *perr
+		e.Path = rt.RelPath(e.Path)
+		return e.Error()
 	}
-	return "", false
 
+	return err.Error()
 }
 
-func ReadFile(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	if IsAndroid() {
-		return object.NewErr(args[0].GetToken(), eh, false, errs.Errs["NOT_ON_ANDROID"])
+func ReadFile(eh *object.ErrorHelper, rt *object.Runtime, args []object.Obj) object.Obj {
+	if osFSOnAndroid(rt) {
+		return object.NewErr(args[0].GetToken(), eh, false, eh.Msg("NOT_ON_ANDROID"))
 	}
-	filename, isOkay := getStringFromArgs(args[0])
+	filename := stringArg(args, 0)
 
-	if !isOkay {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["FILE_PATH_MUST_BE_STRING"])
+	filename, perr := rt.ResolvePath(filename, false)
+	if perr != nil {
+		return object.NewErr(args[0].GetToken(), eh, false, "%s", perr.Error())
 	}
-	d, err := os.ReadFile(filename)
+	d, err := rt.FileSystem().ReadFile(filename)
 	if err != nil {
-		return &object.Error{Msg: "Failed to read file"}
+		return object.NewErr(args[0].GetToken(), eh, true, eh.Msg("FAILED_TO_READ_FILE"), fsErr(rt, err))
 	}
 
 	return &object.String{Value: string(d)}
 }
 
-func CreateEmptyFile(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	if IsAndroid() {
-		return object.NewErr(args[0].GetToken(), eh, false, errs.Errs["NOT_ON_ANDROID"])
+func CreateEmptyFile(eh *object.ErrorHelper, rt *object.Runtime, args []object.Obj) object.Obj {
+	if osFSOnAndroid(rt) {
+		return object.NewErr(args[0].GetToken(), eh, false, eh.Msg("NOT_ON_ANDROID"))
 	}
-	filename, isOkay := getStringFromArgs(args[0])
+	filename := stringArg(args, 0)
 
-	if !isOkay {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["FILE_PATH_MUST_BE_STRING"])
+	filename, perr := rt.ResolvePath(filename, true)
+	if perr != nil {
+		return object.NewErr(args[0].GetToken(), eh, false, "%s", perr.Error())
 	}
 
-	if _, err := os.Stat(filename); err == nil {
+	if _, err := rt.FileSystem().Stat(filename); err == nil {
 		return &object.Boolean{Value: false}
 	}
 
-	f, err := os.Create(filename)
-	if err != nil {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["FAILED_TO_CREATE"], filename)
-
+	if err := rt.FileSystem().WriteFile(filename, nil, 0644); err != nil {
+		return object.NewErr(args[0].GetToken(), eh, true, eh.Msg("FAILED_TO_CREATE"), rt.RelPath(filename))
 	}
 
-	if err := f.Close(); err != nil {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["FAILED_TO_CLOSE_FILE"], filename)
-	} else {
-		return &object.Boolean{Value: true}
-	}
+	return &object.Boolean{Value: true}
 
 }
 
-func WriteToFile(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	if IsAndroid() {
-		return object.NewErr(args[0].GetToken(), eh, false, errs.Errs["NOT_ON_ANDROID"])
+func WriteToFile(eh *object.ErrorHelper, rt *object.Runtime, args []object.Obj) object.Obj {
+	if osFSOnAndroid(rt) {
+		return object.NewErr(args[0].GetToken(), eh, false, eh.Msg("NOT_ON_ANDROID"))
 	}
-	filename, isOkay := getStringFromArgs(args[0])
+	filename := stringArg(args, 0)
 	data := args[1]
-	if !isOkay {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["FILE_PATH_MUST_BE_STRING"])
+
+	filename, perr := rt.ResolvePath(filename, true)
+	if perr != nil {
+		return object.NewErr(args[0].GetToken(), eh, false, "%s", perr.Error())
 	}
 
-	err := os.WriteFile(filename, []byte(data.Inspect()), 0644)
+	err := rt.FileSystem().WriteFile(filename, []byte(data.Inspect()), 0644)
 
 	if err != nil {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["FAILED_TO_WRITE_FILE"], filename)
+		return object.NewErr(args[0].GetToken(), eh, true, eh.Msg("FAILED_TO_WRITE_FILE"), rt.RelPath(filename))
 	}
 
 	return &object.Boolean{Value: true}
 
 }
 
-func FileDirExists(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	if IsAndroid() {
-		return object.NewErr(args[0].GetToken(), eh, false, errs.Errs["NOT_ON_ANDROID"])
+func FileDirExists(eh *object.ErrorHelper, rt *object.Runtime, args []object.Obj) object.Obj {
+	if osFSOnAndroid(rt) {
+		return object.NewErr(args[0].GetToken(), eh, false, eh.Msg("NOT_ON_ANDROID"))
 	}
 	result := false
-	filename, isOkay := getStringFromArgs(args[0])
+	filename := stringArg(args, 0)
 
-	if !isOkay {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["FILE_PATH_MUST_BE_STRING"])
+	filename, perr := rt.ResolvePath(filename, false)
+	if perr != nil {
+		return object.NewErr(args[0].GetToken(), eh, false, "%s", perr.Error())
 	}
 
-	if _, err := os.Stat(filename); err == nil {
+	if _, err := rt.FileSystem().Stat(filename); err == nil {
 		result = true
 	}
 	return &object.Boolean{Value: result}
 }
 
-func DeletePath(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	if IsAndroid() {
-		return object.NewErr(args[0].GetToken(), eh, false, errs.Errs["NOT_ON_ANDROID"])
+func DeletePath(eh *object.ErrorHelper, rt *object.Runtime, args []object.Obj) object.Obj {
+	if osFSOnAndroid(rt) {
+		return object.NewErr(args[0].GetToken(), eh, false, eh.Msg("NOT_ON_ANDROID"))
 	}
-	filename, ok := getStringFromArgs(args[0])
+	filename := stringArg(args, 0)
 
-	if !ok {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["FILE_PATH_MUST_BE_STRING"])
+	filename, perr := rt.ResolvePath(filename, true)
+	if perr != nil {
+		return object.NewErr(args[0].GetToken(), eh, false, "%s", perr.Error())
 	}
 
-	if _, err := os.Stat(filename); err != nil {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["FILE_NOT_EXIST"])
+	if rt.IsRoot(filename) {
+		return object.NewErr(args[0].GetToken(), eh, true, eh.Msg("ROOT_NOT_CHANGEABLE"), stringArg(args, 0))
+	}
+
+	if _, err := rt.FileSystem().Stat(filename); err != nil {
+		return object.NewErr(args[0].GetToken(), eh, true, eh.Msg("FILE_NOT_EXIST"))
 	} else {
-		err := os.RemoveAll(filename)
+		err := rt.FileSystem().RemoveAll(filename)
 		if err != nil {
-			return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["DELETE_FAILED"])
+			return object.NewErr(args[0].GetToken(), eh, true, eh.Msg("DELETE_FAILED"))
 		}
 	}
 	return &object.Boolean{Value: true}
 
 }
 
-func RenameFile(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	if IsAndroid() {
-		return object.NewErr(args[0].GetToken(), eh, false, errs.Errs["NOT_ON_ANDROID"])
+func RenameFile(eh *object.ErrorHelper, rt *object.Runtime, args []object.Obj) object.Obj {
+	if osFSOnAndroid(rt) {
+		return object.NewErr(args[0].GetToken(), eh, false, eh.Msg("NOT_ON_ANDROID"))
 	}
 	//result := false
 
-	targetFile, isOkay := getStringFromArgs(args[0])
+	targetFile := stringArg(args, 0)
+
+	targetFile, perr := rt.ResolvePath(targetFile, true)
+	if perr != nil {
+		return object.NewErr(args[0].GetToken(), eh, false, "%s", perr.Error())
+	}
 
-	if !isOkay {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["FILE_PATH_MUST_BE_STRING"])
+	if rt.IsRoot(targetFile) {
+		return object.NewErr(args[0].GetToken(), eh, true, eh.Msg("ROOT_NOT_CHANGEABLE"), stringArg(args, 0))
 	}
 
-	newName, isOkay2 := getStringFromArgs(args[1])
+	newName := stringArg(args, 1)
 
-	if !isOkay2 {
-		return object.NewErr(args[1].GetToken(), eh, true, errs.Errs["FILE_PATH_MUST_BE_STRING"])
+	newName, perr = rt.ResolvePath(newName, true)
+	if perr != nil {
+		return object.NewErr(args[1].GetToken(), eh, false, "%s", perr.Error())
 	}
 
-	if _, err := os.Stat(targetFile); err != nil {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["FILE_NOT_EXIST"])
+	if _, err := rt.FileSystem().Stat(targetFile); err != nil {
+		return object.NewErr(args[0].GetToken(), eh, true, eh.Msg("FILE_NOT_EXIST"))
 	}
 
-	err := os.Rename(targetFile, newName)
+	err := rt.FileSystem().Rename(targetFile, newName)
 
 	if err != nil {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["RENAME_FAILED"])
+		return object.NewErr(args[0].GetToken(), eh, true, eh.Msg("RENAME_FAILED"))
 	}
 
 	return &object.Boolean{Value: true}
 
 }
 
-func IsAFile(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	if IsAndroid() {
-		return object.NewErr(args[0].GetToken(), eh, false, errs.Errs["NOT_ON_ANDROID"])
+func IsAFile(eh *object.ErrorHelper, rt *object.Runtime, args []object.Obj) object.Obj {
+	if osFSOnAndroid(rt) {
+		return object.NewErr(args[0].GetToken(), eh, false, eh.Msg("NOT_ON_ANDROID"))
 	}
-	target, ok := getStringFromArgs(args[0])
+	target := stringArg(args, 0)
 	result := false
 
-	if !ok {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["FILE_PATH_MUST_BE_STRING"])
+	target, perr := rt.ResolvePath(target, false)
+	if perr != nil {
+		return object.NewErr(args[0].GetToken(), eh, false, "%s", perr.Error())
 	}
 
-	if s, err := os.Stat(target); err != nil {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["FILE_NOT_EXIST"])
+	if s, err := rt.FileSystem().Stat(target); err != nil {
+		return object.NewErr(args[0].GetToken(), eh, true, eh.Msg("FILE_NOT_EXIST"))
 	} else if !s.IsDir() {
 		result = true
 	}
@@ -175,19 +194,20 @@ func IsAFile(eh *object.ErrorHelper, args []object.Obj) object.Obj {
 	return &object.Boolean{Value: result}
 }
 
-func IsADir(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	if IsAndroid() {
-		return object.NewErr(args[0].GetToken(), eh, false, errs.Errs["NOT_ON_ANDROID"])
+func IsADir(eh *object.ErrorHelper, rt *object.Runtime, args []object.Obj) object.Obj {
+	if osFSOnAndroid(rt) {
+		return object.NewErr(args[0].GetToken(), eh, false, eh.Msg("NOT_ON_ANDROID"))
 	}
-	target, ok := getStringFromArgs(args[0])
+	target := stringArg(args, 0)
 	result := false
 
-	if !ok {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["FILE_PATH_MUST_BE_STRING"])
+	target, perr := rt.ResolvePath(target, false)
+	if perr != nil {
+		return object.NewErr(args[0].GetToken(), eh, false, "%s", perr.Error())
 	}
 
-	if s, err := os.Stat(target); err != nil {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["FILE_NOT_EXIST"])
+	if s, err := rt.FileSystem().Stat(target); err != nil {
+		return object.NewErr(args[0].GetToken(), eh, true, eh.Msg("FILE_NOT_EXIST"))
 	} else if s.IsDir() {
 		result = true
 	}
@@ -195,38 +215,27 @@ func IsADir(eh *object.ErrorHelper, args []object.Obj) object.Obj {
 	return &object.Boolean{Value: result}
 }
 
-func AppendLineToFile(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	if IsAndroid() {
-		return object.NewErr(args[0].GetToken(), eh, false, errs.Errs["NOT_ON_ANDROID"])
-	}
-	filename, ok := getStringFromArgs(args[0])
-	if !ok {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["FILE_PATH_MUST_BE_STRING"])
+func AppendLineToFile(eh *object.ErrorHelper, rt *object.Runtime, args []object.Obj) object.Obj {
+	if osFSOnAndroid(rt) {
+		return object.NewErr(args[0].GetToken(), eh, false, eh.Msg("NOT_ON_ANDROID"))
 	}
+	filename := stringArg(args, 0)
 
-	data, ok2 := getStringFromArgs(args[1])
-
-	if !ok2 {
-		return object.NewErr(args[1].GetToken(), eh, true, errs.Errs["DATA_MUST_BE_STRING"])
+	filename, perr := rt.ResolvePath(filename, true)
+	if perr != nil {
+		return object.NewErr(args[0].GetToken(), eh, false, "%s", perr.Error())
 	}
 
-	if s, err := os.Stat(filename); err != nil {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["FILE_NOT_EXIST"])
+	data := stringArg(args, 1)
+
+	if s, err := rt.FileSystem().Stat(filename); err != nil {
+		return object.NewErr(args[0].GetToken(), eh, true, eh.Msg("FILE_NOT_EXIST"))
 	} else {
 		if s.IsDir() {
-			return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["TARGET_IS_DIR"])
+			return object.NewErr(args[0].GetToken(), eh, true, eh.Msg("TARGET_IS_DIR"))
 		} else {
-			f, err := os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, 0644)
-
-			if err != nil {
-
-				return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["FAILED_OPEN_FILE"])
-			}
-
-			defer f.Close()
-
-			if _, err := f.WriteString(data); err != nil {
-				return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["FAILED_TO_WRITE_DATA"])
+			if err := rt.FileSystem().AppendFile(filename, []byte(data)); err != nil {
+				return object.NewErr(args[0].GetToken(), eh, true, eh.Msg("FAILED_TO_WRITE_DATA"))
 			}
 		}
 
@@ -236,36 +245,38 @@ func AppendLineToFile(eh *object.ErrorHelper, args []object.Obj) object.Obj {
 
 }
 
-func ListDir(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	if IsAndroid() {
-		return object.NewErr(args[0].GetToken(), eh, false, errs.Errs["NOT_ON_ANDROID"])
+func ListDir(eh *object.ErrorHelper, rt *object.Runtime, args []object.Obj) object.Obj {
+	if osFSOnAndroid(rt) {
+		return object.NewErr(args[0].GetToken(), eh, false, eh.Msg("NOT_ON_ANDROID"))
 	}
 	d := args[0]
-	dirname, ok := getStringFromArgs(d)
-	if !ok {
-		return object.NewErr(d.GetToken(), eh, true, errs.Errs["FILENAME_MUST_BE_STRING"])
+	dirname := stringArg(args, 0)
+
+	dirname, perr := rt.ResolvePath(dirname, false)
+	if perr != nil {
+		return object.NewErr(d.GetToken(), eh, false, "%s", perr.Error())
 	}
 
-	if f, err := os.Stat(dirname); err != nil {
-		return object.NewErr(d.GetToken(), eh, true, errs.Errs["FILE_NOT_EXIST"])
+	if f, err := rt.FileSystem().Stat(dirname); err != nil {
+		return object.NewErr(d.GetToken(), eh, true, eh.Msg("FILE_NOT_EXIST"))
 	} else {
 		if !f.IsDir() {
-			return object.NewErr(d.GetToken(), eh, true, errs.Errs["TARGET_NO_DIR"])
+			return object.NewErr(d.GetToken(), eh, true, eh.Msg("TARGET_NO_DIR"))
 		}
 
 		result := []object.Obj{}
 
-		err := filepath.Walk(dirname, func(path string, info fs.FileInfo, err error) error {
+		err := vfs.Walk(rt.FileSystem(), dirname, func(path string, info fs.FileInfo, err error) error {
 			if err != nil {
 				return err
 			}
 
-			result = append(result, &object.String{Value: path})
+			result = append(result, &object.String{Value: rt.RelPath(path)})
 			return nil
 		})
 
 		if err != nil {
-			object.NewErr(d.GetToken(), eh, true, errs.Errs["DIR_LIST_FAILED"])
+			return object.NewErr(d.GetToken(), eh, true, eh.Msg("DIR_LIST_FAILED"), fsErr(rt, err))
 		}
 
 		return &object.Array{Elms: result}
@@ -273,3 +284,41 @@ func ListDir(eh *object.ErrorHelper, args []object.Obj) object.Obj {
 	}
 
 }
+
+// readFileArg reads the file at the path given as arg, for the modules
+// which read files of their own formats
+func readFileArg(eh *object.ErrorHelper, rt *object.Runtime, arg object.Obj) (string, object.Obj) {
+	if osFSOnAndroid(rt) {
+		return "", object.NewErr(arg.GetToken(), eh, false, eh.Msg("NOT_ON_ANDROID"))
+	}
+
+	filename, perr := rt.ResolvePath(arg.(*object.String).Value, false)
+	if perr != nil {
+		return "", object.NewErr(arg.GetToken(), eh, false, "%s", perr.Error())
+	}
+
+	d, err := rt.FileSystem().ReadFile(filename)
+	if err != nil {
+		return "", object.NewErr(arg.GetToken(), eh, true, eh.Msg("FAILED_TO_READ_FILE"), fsErr(rt, err))
+	}
+
+	return string(d), nil
+}
+
+// writeFileArg is like readFileArg but writes data to the file
+func writeFileArg(eh *object.ErrorHelper, rt *object.Runtime, arg object.Obj, data string) object.Obj {
+	if osFSOnAndroid(rt) {
+		return object.NewErr(arg.GetToken(), eh, false, eh.Msg("NOT_ON_ANDROID"))
+	}
+
+	filename, perr := rt.ResolvePath(arg.(*object.String).Value, true)
+	if perr != nil {
+		return object.NewErr(arg.GetToken(), eh, false, "%s", perr.Error())
+	}
+
+	if err := rt.FileSystem().WriteFile(filename, []byte(data), 0644); err != nil {
+		return object.NewErr(arg.GetToken(), eh, true, eh.Msg("FAILED_TO_WRITE_FILE"), rt.RelPath(filename))
+	}
+
+	return &object.Boolean{Value: true}
+}
@@ -0,0 +1,81 @@
+package stdlib
+
+import (
+	"go.cs.palashbauri.in/pankti/object"
+	"go.cs.palashbauri.in/pankti/token"
+)
+
+type NamedBuiltin struct {
+	Name    string
+	Builtin *object.Builtin
+}
+
+// Builtins are available everywhere without including any module.
+// The compiler refers to them by their index, so new builtins must only
+// be appended to the end of this list
+var Builtins = []NamedBuiltin{
+	{"দৈর্ঘ্য", native(sig("দৈর্ঘ্য", param("মান", array, str)), Length)},
+	{"len", native(sig("len", param("মান", array, str)), Length)},
+	{"sethv", withEnv(
+		sig("sethv", param("অবিধান", hash), param("চাবি", str, num, object.BOOL_OBJ), param("মান")),
+		SetHashTableElm,
+	)},
+	{"getkeys", withEnv(
+		sig("getkeys", param("অবিধান", hash)),
+		func(eh *object.ErrorHelper, env *object.EnvMap, caller token.Token, args []object.Obj) object.Obj {
+			return GetAllKVsOfHashTable(true, eh, env, caller, args)
+		},
+	)},
+	{"getvals", withEnv(
+		sig("getvals", param("অবিধান", hash)),
+		func(eh *object.ErrorHelper, env *object.EnvMap, caller token.Token, args []object.Obj) object.Obj {
+			return GetAllKVsOfHashTable(false, eh, env, caller, args)
+		},
+	)},
+	{"দেখাও", withEnv(variadic("দেখাও", param("মান")), show)},
+	{"show", withEnv(variadic("show", param("মান")), show)},
+	{"dekhau", withEnv(variadic("dekhau", param("মান")), show)},
+	{"সাহায্য", native(sig("সাহায্য", param("কাজ", function...)), Help)},
+	{"help", native(sig("help", param("কাজ", function...)), Help)},
+	{"রুন_দৈর্ঘ্য", native(sig("রুন_দৈর্ঘ্য", param("লেখা", str)), RuneLength)},
+	{"rune_len", native(sig("rune_len", param("লেখা", str)), RuneLength)},
+	{"বাইট_দৈর্ঘ্য", native(sig("বাইট_দৈর্ঘ্য", param("লেখা", str)), ByteLength)},
+	{"byte_len", native(sig("byte_len", param("লেখা", str)), ByteLength)},
+}
+
+func init() {
+	for i := range Builtins {
+		Builtins[i].Name = token.Normalize(Builtins[i].Name)
+	}
+}
+
+// function are the types of values which can be called
+var function = []object.ObjType{object.FUNC_OBJ, object.CLOSURE_OBJ, object.BUILTIN_OBJ}
+
+func show(_ *object.ErrorHelper, env *object.EnvMap, _ token.Token, args []object.Obj) object.Obj {
+	return Show(env, args)
+}
+
+// withEnv is like native but for functions which also need the
+// environment and the caller token
+func withEnv(
+	sig *object.Signature,
+	fn func(*object.ErrorHelper, *object.EnvMap, token.Token, []object.Obj) object.Obj,
+) *object.Builtin {
+	return &object.Builtin{
+		Sig: sig,
+		Fn: func(eh *object.ErrorHelper, env *object.EnvMap, caller token.Token, args ...object.Obj) object.Obj {
+			return fn(eh, env, caller, args)
+		},
+	}
+}
+
+func GetBuiltin(name string) (*object.Builtin, bool) {
+	for _, b := range Builtins {
+		if b.Name == name {
+			return b.Builtin, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,180 @@
+package stdlib
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"go.cs.palashbauri.in/pankti/number"
+	"go.cs.palashbauri.in/pankti/object"
+	"go.cs.palashbauri.in/pankti/token"
+)
+
+// DecodeJSON reads a JSON text into Pankti values. Integers stay exact
+// however large they are, and other numbers become decimal numbers
+func DecodeJSON(eh *object.ErrorHelper, _ *object.EnvMap, caller token.Token, args []object.Obj) object.Obj {
+	dec := json.NewDecoder(strings.NewReader(stringArg(args, 0)))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return object.NewErr(caller, eh, true, eh.Msg("JSON_DECODE_FAILED"), err.Error())
+	}
+
+	// only one value is allowed in the text
+	if _, err := dec.Token(); err != io.EOF {
+		return object.NewErr(caller, eh, true, eh.Msg("JSON_DECODE_FAILED"), "invalid data after the value")
+	}
+
+	return fromJSON(v)
+}
+
+func fromJSON(v interface{}) object.Obj {
+	switch v := v.(type) {
+	case bool:
+		return &object.Boolean{Value: v}
+	case json.Number:
+		n := number.Number{}
+		n.SetValue(string(v))
+		return &object.Number{Value: n, IsInt: n.IsInt}
+	case string:
+		return &object.String{Value: v}
+	case []interface{}:
+		result := &object.Array{Elms: []object.Obj{}}
+		for _, e := range v {
+			result.Elms = append(result.Elms, fromJSON(e))
+		}
+
+		return result
+	case map[string]interface{}:
+		result := &object.Hash{Pairs: map[object.HashKey]object.HashPair{}}
+		for k, e := range v {
+			key := &object.String{Value: k}
+			result.Pairs[key.HashKey()] = object.HashPair{Key: key, Value: fromJSON(e)}
+		}
+
+		return result
+	default:
+		return &object.Null{}
+	}
+}
+
+// jsonOptions are the options of EncodeJSON, by their bengali and
+// english names
+var jsonOptions = map[string]string{
+	"সুন্দর": "pretty",
+	"pretty": "pretty",
+}
+
+// EncodeJSON writes a value as JSON. It can be given a hash of options:
+// `সুন্দর`/`pretty` indents the text over several lines. Hashes do not keep
+// the order of their keys, so the keys are always written in sorted order
+// and the same value gives the same text on every run
+func EncodeJSON(eh *object.ErrorHelper, _ *object.EnvMap, caller token.Token, args []object.Obj) object.Obj {
+	options := map[string]bool{}
+	for _, o := range args[1:] {
+		for _, p := range o.(*object.Hash).Pairs {
+			name, ok := jsonOptions[token.Normalize(p.Key.Inspect())]
+			on, isBool := p.Value.(*object.Boolean)
+			if !ok || !isBool {
+				return object.NewErr(caller, eh, true, eh.Msg("JSON_UNKNOWN_OPTION"), p.Key.Inspect())
+			}
+
+			options[name] = on.Value
+		}
+	}
+
+	var out bytes.Buffer
+	if err := writeJSON(eh, caller, &out, args[0]); err != nil {
+		return err
+	}
+
+	if options["pretty"] {
+		var pretty bytes.Buffer
+		json.Indent(&pretty, out.Bytes(), "", "  ")
+		return &object.String{Value: pretty.String()}
+	}
+
+	return &object.String{Value: out.String()}
+}
+
+// writeJSON writes o to out as JSON; it returns an error at the caller if
+// a value can not be written or two keys of a hash are written the same
+func writeJSON(eh *object.ErrorHelper, caller token.Token, out *bytes.Buffer, o object.Obj) *object.Error {
+	switch o := o.(type) {
+	case *object.Null:
+		out.WriteString("null")
+	case *object.Boolean:
+		out.WriteString(o.Inspect())
+	case *object.Number:
+		if f, ok := o.Value.Value.(*number.FloatNumber); ok {
+			if f.Value.IsInf() {
+				return object.NewErr(caller, eh, true, eh.Msg("JSON_CANNOT_ENCODE"), eh.TypeName(o.Type()))
+			}
+
+			out.WriteString(f.Value.Text('g', -1))
+		} else {
+			out.WriteString(o.Inspect())
+		}
+	case *object.String:
+		out.WriteString(jsonString(o.Value))
+	case *object.Array:
+		out.WriteString("[")
+		for i, e := range o.Elms {
+			if i > 0 {
+				out.WriteString(",")
+			}
+
+			if err := writeJSON(eh, caller, out, e); err != nil {
+				return err
+			}
+		}
+		out.WriteString("]")
+	case *object.Hash:
+		pairs := []object.HashPair{}
+		for _, p := range o.Pairs {
+			pairs = append(pairs, p)
+		}
+
+		sort.Slice(pairs, func(i, j int) bool {
+			return pairs[i].Key.Inspect() < pairs[j].Key.Inspect()
+		})
+
+		out.WriteString("{")
+		for i, p := range pairs {
+			// keys which are not strings are written as strings, so 1 and
+			// "1" would become the same key
+			key := p.Key.Inspect()
+			if i > 0 {
+				if key == pairs[i-1].Key.Inspect() {
+					return object.NewErr(caller, eh, true, eh.Msg("JSON_DUPLICATE_KEY"), key)
+				}
+
+				out.WriteString(",")
+			}
+
+			out.WriteString(jsonString(key) + ":")
+			if err := writeJSON(eh, caller, out, p.Value); err != nil {
+				return err
+			}
+		}
+		out.WriteString("}")
+	default:
+		return object.NewErr(caller, eh, true, eh.Msg("JSON_CANNOT_ENCODE"), eh.TypeName(o.Type()))
+	}
+
+	return nil
+}
+
+// jsonString quotes s for JSON, leaving characters such as < and &
+// as they are
+func jsonString(s string) string {
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+
+	return strings.TrimSuffix(out.String(), "\n")
+}
@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"go.cs.palashbauri.in/pankti/constants"
-	"go.cs.palashbauri.in/pankti/errs"
 	"go.cs.palashbauri.in/pankti/number"
 	"go.cs.palashbauri.in/pankti/object"
 	"go.cs.palashbauri.in/pankti/token"
@@ -54,18 +53,20 @@ func getInt(arg object.Obj) (int64, bool) {
 	//return 0,false
 }
 
-func DoListSum(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	if args[0].Type() != object.ARRAY_OBJ {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["SUM_ONLY_LISTS"])
-	}
+// floatArg returns the value of a number argument as a float, which the
+// signature of the builtin has already checked
+func floatArg(args []object.Obj, i int) float64 {
+	fv, _ := args[i].(*object.Number).Value.GetAsFloat()
+	return fv
+}
 
-	inputList := args[0].(*object.Array)
+func DoListSum(eh *object.ErrorHelper, args []object.Obj) object.Obj {
 	result := float64(0)
-	for _, item := range inputList.Elms {
+	for _, item := range args[0].(*object.Array).Elms {
 		if fv, ok := getFloat(item); ok {
 			result += fv
 		} else {
-			return object.NewErr(item.GetToken(), eh, true, errs.Errs["SUM_ARRAY_ALL_NUM"])
+			return object.NewErr(item.GetToken(), eh, true, eh.Msg("SUM_ARRAY_ALL_NUM"))
 		}
 	}
 
@@ -87,13 +88,13 @@ func GetGCD(eh *object.ErrorHelper, _ token.Token, args []object.Obj) object.Obj
 	temp, ok := getInt(args[0])
 
 	if !ok {
-		return object.NewErr(args[0].GetToken(), eh, false, errs.Errs["NOT_ALL_ARE_INT"], constants.FNames["gcd"])
+		return object.NewErr(args[0].GetToken(), eh, false, eh.Msg("NOT_ALL_ARE_INT"), constants.FNames["gcd"])
 	}
 
 	for index, item := range args[1:] {
 		b, ok2 := getInt(item)
 		if !ok2 {
-			return object.NewErr(args[index].GetToken(), eh, true, errs.Errs["NOT_ALL_ARE_INT"], constants.FNames["gcd"])
+			return object.NewErr(args[index].GetToken(), eh, true, eh.Msg("NOT_ALL_ARE_INT"), constants.FNames["gcd"])
 		}
 		temp = gcd(temp, b)
 	}
@@ -108,13 +109,13 @@ func lcm(a, b int64) int64 {
 func GetLCM(eh *object.ErrorHelper, args []object.Obj) object.Obj {
 	tempA, ok := getInt(args[0])
 	if !ok {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["TEMPLATE_NOT_ALL_INT"])
+		return object.NewErr(args[0].GetToken(), eh, true, eh.Msg("TEMPLATE_NOT_ALL_INT"))
 	}
 	tempB, okB := getInt(args[1])
 	if !okB {
 		//eturn NOT_ALL_INT
 
-		return object.NewErr(args[1].GetToken(), eh, true, errs.Errs["TEMPLATE_NOT_ALL_INT"])
+		return object.NewErr(args[1].GetToken(), eh, true, eh.Msg("TEMPLATE_NOT_ALL_INT"))
 	}
 
 	result := tempA * tempB / gcd(tempA, tempB)
@@ -122,7 +123,7 @@ func GetLCM(eh *object.ErrorHelper, args []object.Obj) object.Obj {
 	for _, item := range args[2:] {
 		b, ok := getInt(item)
 		if !ok {
-			return object.NewErr(item.GetToken(), eh, true, errs.Errs["TEMPLATE_NOT_ALL_INT"])
+			return object.NewErr(item.GetToken(), eh, true, eh.Msg("TEMPLATE_NOT_ALL_INT"))
 		}
 		result = lcm(result, b)
 	}
@@ -130,154 +131,60 @@ func GetLCM(eh *object.ErrorHelper, args []object.Obj) object.Obj {
 	return object.MakeIntNumber(result)
 }
 
-func DoSqrt(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	fValue, ok := getFloat(args[0])
-	if !ok {
-		//fmt.Println(args[0].GetToken())
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["TEMPLATE_NOT_ALL_INT"], constants.FNames["sqrt"], constants.FNames["int"])
-	}
-	return object.MakeFloatNumber(math.Sqrt(fValue))
-
+func DoSqrt(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return object.MakeFloatNumber(math.Sqrt(floatArg(args, 0)))
 }
 
-func DoPow(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-
-	if fv, ok := getFloat(args[0]); ok {
-		if fv2, ok2 := getFloat(args[1]); ok2 {
-			return object.MakeFloatNumber(math.Pow(fv, fv2))
-		} else {
-
-			return object.NewErr(args[1].GetToken(), eh, true, errs.Errs["TEMPLATE_NOT_ALL_INT"], constants.FNames["pow"], constants.FNames["num"])
-		}
-
-	} else {
-
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["TEMPLATE_NOT_ALL_INT"], constants.FNames["pow"], constants.FNames["num"])
-	}
-
+func DoPow(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return object.MakeFloatNumber(math.Pow(floatArg(args, 0), floatArg(args, 1)))
 }
 
-func Log10(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	fv, ok := getFloat(args[0])
-	if !ok {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["TEMPLATE_NOT_ONE_TEMPALTE"], constants.FNames["log"], constants.FNames["num"])
-	}
-	return object.MakeFloatNumber(math.Log10(fv))
+func Log10(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return object.MakeFloatNumber(math.Log10(floatArg(args, 0)))
 }
 
-func LogE(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	fv, ok := getFloat(args[0])
-	if !ok {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["TEMPLATE_NOT_ONE_TEMPALTE"], constants.FNames["log"], constants.FNames["num"])
-	}
-	return object.MakeFloatNumber(math.Log(fv))
+func LogE(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return object.MakeFloatNumber(math.Log(floatArg(args, 0)))
 }
 
-func LogX(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	if fv, ok := getFloat(args[0]); ok {
-		if len(args) == 1 {
-			return object.MakeFloatNumber(math.Log(fv))
-		} else if len(args) >= 2 {
-			if base, okay := getFloat(args[1]); okay {
-				return object.MakeFloatNumber(math.Log(fv) / math.Log(base))
-			} else {
-
-				return object.NewErr(args[1].GetToken(), eh, true, errs.Errs["TEMPLATE_NOT_ONE_TEMPALTE"], constants.FNames["log"], constants.FNames["num"])
-			}
-
-		}
-
-	} else {
-
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["TEMPLATE_NOT_ONE_TEMPALTE"], constants.FNames["log"], constants.FNames["num"])
-	}
-
-	return object.NewErr(args[0].GetToken(), eh, false, errs.Errs["TEMPLATE_NOT_ALL_INT"], constants.FNames["log"], constants.FNames["num"])
+func LogX(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return object.MakeFloatNumber(math.Log(floatArg(args, 0)) / math.Log(floatArg(args, 1)))
 }
 
-func Cosine(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	fv, ok := getFloat(args[0])
-	if !ok {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["TEMPLATE_NOT_ONE_TEMPALTE"], constants.FNames["cos"], constants.FNames["num"])
-	}
-
-	return object.MakeFloatNumber(math.Cos(fv))
+func Cosine(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return object.MakeFloatNumber(math.Cos(floatArg(args, 0)))
 }
 
-func Acos(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	if fv, ok := getFloat(args[0]); ok {
-		return object.MakeFloatNumber(math.Acos(fv))
-	}
-
-	return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["TEMPLATE_NOT_ONE_TEMPALTE"], constants.FNames["cos"], constants.FNames["num"])
+func Acos(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return object.MakeFloatNumber(math.Acos(floatArg(args, 0)))
 }
 
-func Sine(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	fv, ok := getFloat(args[0])
-	if !ok {
-
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["TEMPLATE_NOT_ONE_TEMPALTE"], constants.FNames["sin"], constants.FNames["num"])
-	}
-
-	return object.MakeFloatNumber(math.Sin(fv))
+func Sine(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return object.MakeFloatNumber(math.Sin(floatArg(args, 0)))
 }
 
-func Asin(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	if fv, ok := getFloat(args[0]); ok {
-		return object.MakeFloatNumber(math.Asin(fv))
-	}
-	return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["TEMPLATE_NOT_ONE_TEMPALTE"], constants.FNames["sin"], constants.FNames["num"])
+func Asin(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return object.MakeFloatNumber(math.Asin(floatArg(args, 0)))
 }
 
-func Tangent(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	if fv, ok := getFloat(args[0]); ok {
-		return object.MakeFloatNumber(math.Tan(fv))
-	}
-
-	return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["TEMPLATE_NOT_ONE_TEMPALTE"], constants.FNames["tan"], constants.FNames["num"])
+func Tangent(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return object.MakeFloatNumber(math.Tan(floatArg(args, 0)))
 }
 
-func Atan(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	if fv, ok := getFloat(args[0]); ok {
-		return object.MakeFloatNumber(math.Atan(fv))
-	}
-
-	return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["TEMPLATE_NOT_ONE_TEMPALTE"], constants.FNames["tan"], constants.FNames["num"])
-
+func Atan(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return object.MakeFloatNumber(math.Atan(floatArg(args, 0)))
 }
 
-func Atan2(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	if fv, ok := getFloat(args[0]); ok {
-		if fv2, ok2 := getFloat(args[1]); ok2 {
-			return object.MakeFloatNumber(math.Atan2(fv, fv2))
-		} else {
-
-			return object.NewErr(args[1].GetToken(), eh, true, errs.Errs["TEMPLATE_NOT_ALL_INT"], constants.FNames["tan"], constants.FNames["num"])
-		}
-	}
-
-	return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["TEMPLATE_NOT_ALL_INT"], constants.FNames["tan"], constants.FNames["num"])
-
+func Atan2(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return object.MakeFloatNumber(math.Atan2(floatArg(args, 0), floatArg(args, 1)))
 }
 
-func ToDegree(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	if fv, ok := getFloat(args[0]); ok {
-		dg := fv * (180 / math.Pi)
-		return object.MakeFloatNumber(dg)
-	}
-
-	return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["TEMPLATE_NOT_ONE_TEMPALTE"], constants.FNames["degree"], constants.FNames["num"])
-
+func ToDegree(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return object.MakeFloatNumber(floatArg(args, 0) * (180 / math.Pi))
 }
 
-func ToRadians(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	if fv, ok := getFloat(args[0]); ok {
-		rad := fv * (math.Pi / 180)
-		return object.MakeFloatNumber(rad)
-	}
-
-	return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["TEMPLATE_NOT_ONE_TEMPALTE"], constants.FNames["rad"], constants.FNames["num"])
-
+func ToRadians(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return object.MakeFloatNumber(floatArg(args, 0) * (math.Pi / 180))
 }
 
 func ToNumber(eh *object.ErrorHelper, args []object.Obj) object.Obj {
@@ -291,22 +198,17 @@ func ToNumber(eh *object.ErrorHelper, args []object.Obj) object.Obj {
 			result = 0.0
 		}
 	case object.STRING_OBJ:
-		t := target.(*object.String).Value
+		t := number.ToASCIIDigits(target.(*object.String).Value)
 
 		v, err := strconv.ParseFloat(t, 64)
 
 		if err != nil {
-			return object.NewErr(target.GetToken(), eh, true, errs.Errs["CANNOT_PARSE_STRING_AS_NUM"])
+			return object.NewErr(target.GetToken(), eh, true, eh.Msg("CANNOT_PARSE_STRING_AS_NUM"))
 		}
 
 		result = v
 	case object.NUM_OBJ:
-		v, _ := getFloat(target)
-		result = v
-
-	default:
-		return object.NewErr(target.GetToken(), eh, true, errs.Errs["CANNOT_PARSE_AS_NUM"])
-
+		result = floatArg(args, 0)
 	}
 
 	return object.MakeFloatNumber(result)
@@ -327,22 +229,18 @@ func ConvertToInt(eh *object.ErrorHelper, args []object.Obj) object.Obj {
 			result = 0
 		}
 	case object.STRING_OBJ:
-		t := target.(*object.String).Value
+		t := number.ToASCIIDigits(target.(*object.String).Value)
 
 		v, err := strconv.Atoi(t)
 
 		if err != nil {
-			return object.NewErr(target.GetToken(), eh, true, errs.Errs["CANNOT_PARSE_STRING_AS_NUM"])
+			return object.NewErr(target.GetToken(), eh, true, eh.Msg("CANNOT_PARSE_STRING_AS_NUM"))
 		}
 
 		result = int64(v)
 	case object.NUM_OBJ:
 		v, _ := getInt(target)
 		result = v
-
-	default:
-		return object.NewErr(target.GetToken(), eh, true, errs.Errs["CANNOT_PARSE_AS_NUM"])
-
 	}
 
 	return object.MakeIntNumber(result)
@@ -354,7 +252,7 @@ func GenerateRandom(eh *object.ErrorHelper, args []object.Obj) object.Obj {
 	rand.Seed(time.Now().UnixNano())
 	n, ok := getIntFromArg(args[0])
 	if !ok {
-		object.NewErr(args[0].GetToken(), eh, true, errs.Errs["CANNOT_PARSE_AS_NUM"])
+		return object.NewErr(args[0].GetToken(), eh, true, eh.Msg("CANNOT_PARSE_AS_NUM"))
 	}
 
 	return object.MakeIntNumber(int64(rand.Intn(int(n))))
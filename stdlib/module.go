@@ -0,0 +1,204 @@
+package stdlib
+
+import (
+	"bytes"
+	"sort"
+
+	"go.cs.palashbauri.in/pankti/constants"
+	"go.cs.palashbauri.in/pankti/object"
+	"go.cs.palashbauri.in/pankti/token"
+)
+
+// Module is a standard library module implemented natively in Go.
+// Builtins and Values become members of the module once it is included
+// with `anoyon`; Source is optional Pankti code which is evaluated after
+// them and can use them directly by name.
+type Module struct {
+	Name     string
+	Builtins map[string]*object.Builtin
+	Values   map[string]object.Obj
+	Source   string
+}
+
+// Members returns the names of all builtins and values of the module in
+// a stable order
+func (m *Module) Members() []string {
+	names := []string{}
+
+	for name := range m.Builtins {
+		names = append(names, name)
+	}
+
+	for name := range m.Values {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// Member returns the builtin or value exported by the module as `name`
+func (m *Module) Member(name string) (object.Obj, bool) {
+	if b, ok := m.Builtins[name]; ok {
+		return b, true
+	}
+
+	v, ok := m.Values[name]
+	return v, ok
+}
+
+var modules = map[string]*Module{}
+
+func RegisterModule(m *Module) {
+	// the names of the builtins are already normalized by sig
+	values := map[string]object.Obj{}
+	for name, v := range m.Values {
+		values[token.Normalize(name)] = v
+	}
+	m.Values = values
+
+	modules[m.Name] = m
+}
+
+// GetModule finds a registered module either by its english name or by
+// its bengali name
+func GetModule(name string) (*Module, bool) {
+	if enName, ok := constants.GetStdName(name); ok {
+		name = enName
+	}
+
+	m, ok := modules[name]
+	return m, ok
+}
+
+// Modules returns all registered modules sorted by their names
+func Modules() []*Module {
+	mods := []*Module{}
+	for _, m := range modules {
+		mods = append(mods, m)
+	}
+
+	sort.Slice(mods, func(i, j int) bool { return mods[i].Name < mods[j].Name })
+	return mods
+}
+
+// Help returns the signatures of all the builtins of the module, one per
+// line, followed by the names of the values it exports
+func (m *Module) Help() string {
+	var out bytes.Buffer
+
+	for _, name := range m.Members() {
+		if b, ok := m.Builtins[name]; ok && b.Sig != nil {
+			out.WriteString(b.Sig.String() + "\n")
+		} else {
+			out.WriteString(name + "\n")
+		}
+	}
+
+	return out.String()
+}
+
+// native makes a builtin out of a stdlib function; the arguments are
+// validated against the signature before the function is called
+func native(
+	sig *object.Signature,
+	fn func(*object.ErrorHelper, []object.Obj) object.Obj,
+) *object.Builtin {
+	return &object.Builtin{
+		Sig: sig,
+		Fn: func(eh *object.ErrorHelper, env *object.EnvMap, caller token.Token, args ...object.Obj) object.Obj {
+			return fn(atCaller(eh, caller), args)
+		},
+	}
+}
+
+// atCaller returns eh for the builtin called at caller, so that errors
+// about arguments without a place in the source still show the call
+func atCaller(eh *object.ErrorHelper, caller token.Token) *object.ErrorHelper {
+	if eh == nil {
+		return &object.ErrorHelper{Caller: caller}
+	}
+
+	ceh := *eh
+	ceh.Caller = caller
+	return &ceh
+}
+
+// nativeNoArg is like native but for functions without any arguments
+func nativeNoArg(name string, fn func() object.Obj) *object.Builtin {
+	return native(sig(name), func(_ *object.ErrorHelper, _ []object.Obj) object.Obj {
+		return fn()
+	})
+}
+
+// nativeRt is like native but for functions which need the runtime of
+// the program, such as the ones which check its permissions
+func nativeRt(
+	sig *object.Signature,
+	fn func(*object.ErrorHelper, *object.Runtime, []object.Obj) object.Obj,
+) *object.Builtin {
+	return &object.Builtin{
+		Sig: sig,
+		Fn: func(eh *object.ErrorHelper, env *object.EnvMap, caller token.Token, args ...object.Obj) object.Obj {
+			return fn(atCaller(eh, caller), env.GetRuntime(), args)
+		},
+	}
+}
+
+// requires makes a builtin return a permission error, instead of being
+// called, when `check` fails for the runtime of the program
+func requires(check func(*object.Runtime) error, b *object.Builtin) *object.Builtin {
+	fn := b.Fn
+	b.Fn = func(eh *object.ErrorHelper, env *object.EnvMap, caller token.Token, args ...object.Obj) object.Obj {
+		if err := check(env.GetRuntime()); err != nil {
+			return object.NewErr(caller, eh, false, "%s", err.Error())
+		}
+
+		return fn(eh, env, caller, args...)
+	}
+
+	return b
+}
+
+// sig makes the signature of a builtin; the name is normalized like the
+// identifiers of the programs which call it
+func sig(name string, params ...object.Param) *object.Signature {
+	return &object.Signature{Name: token.Normalize(name), Params: params}
+}
+
+// variadic is like sig but the last parameter can be repeated
+func variadic(name string, params ...object.Param) *object.Signature {
+	return &object.Signature{Name: token.Normalize(name), Params: params, Variadic: true}
+}
+
+func param(name string, types ...object.ObjType) object.Param {
+	return object.Param{Name: name, Types: types}
+}
+
+// builtins makes the member map of a module from builtins, using the
+// names from their signatures
+func builtins(bs ...*object.Builtin) map[string]*object.Builtin {
+	result := map[string]*object.Builtin{}
+	for _, b := range bs {
+		result[b.Sig.Name] = b
+	}
+
+	return result
+}
+
+// aliased adds the builtins of bs again under their english names, given
+// as a map of the bengali names to the english ones
+func aliased(bs map[string]*object.Builtin, english map[string]string) map[string]*object.Builtin {
+	for bn, en := range english {
+		b, ok := bs[token.Normalize(bn)]
+		if !ok {
+			panic("no builtin called " + bn)
+		}
+
+		alias := *b
+		alias.Sig = &object.Signature{Name: en, Params: b.Sig.Params, Variadic: b.Sig.Variadic}
+		bs[en] = &alias
+	}
+
+	return bs
+}
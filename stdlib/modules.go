@@ -0,0 +1,302 @@
+package stdlib
+
+import (
+	"math"
+
+	"go.cs.palashbauri.in/pankti/object"
+	"go.cs.palashbauri.in/pankti/token"
+)
+
+const mathSource = `
+ধরি যোগ = একটি কাজ(ক , খ)
+    ক + খ
+শেষ
+
+ধরি বিয়োগ = একটি কাজ(ক, খ)
+    ক - খ
+শেষ
+
+ধরি গুন = একটি কাজ(ক , খ)
+    ক * খ
+শেষ
+
+ধরি ভাগ = একটি কাজ(ক , খ)
+    ক / খ
+শেষ
+
+ধরি বড়_কি = একটি কাজ(ক , খ)
+    ক > খ
+শেষ
+
+ধরি ছোট_কি = একটি কাজ(ক, খ)
+    ক < খ
+শেষ
+`
+
+const (
+	num   = object.NUM_OBJ
+	str   = object.STRING_OBJ
+	array = object.ARRAY_OBJ
+	hash  = object.HASH_OBJ
+)
+
+// permission checks for requires
+var (
+	osInfo = (*object.Runtime).CheckOSInfo
+	stdin  = (*object.Runtime).CheckStdin
+)
+
+func init() {
+	RegisterModule(&Module{
+		Name: "math",
+		Builtins: builtins(
+			native(sig("বর্গমূল", param("সংখ্যা", num)), DoSqrt),
+			native(sig("লগ_দশ", param("সংখ্যা", num)), Log10),
+			native(sig("লিস্ট_যোগ", param("তালিকা", array)), DoListSum),
+			native(variadic("গসাগু", param("সংখ্যা", num), param("বাকি", num)),
+				func(eh *object.ErrorHelper, args []object.Obj) object.Obj {
+					return GetGCD(eh, token.Token{}, args)
+				},
+			),
+			native(variadic("লসাগু", param("ক", num), param("খ", num), param("বাকি", num)), GetLCM),
+			native(sig("লগ_ন্যাচারাল", param("সংখ্যা", num)), LogE),
+			native(sig("লগ_বেস", param("সংখ্যা", num), param("ভিত্তি", num)), LogX),
+			native(sig("কস", param("কোণ", num)), Cosine),
+			native(sig("সাইন", param("কোণ", num)), Sine),
+			native(sig("ট্যান", param("কোণ", num)), Tangent),
+			native(sig("রেডিয়ান_থেকে_ডিগ্রি", param("কোণ", num)), ToDegree),
+			native(sig("ডিগ্রি_থেকে_রেডিয়ান", param("কোণ", num)), ToRadians),
+			native(sig("পাওয়ার", param("ভিত্তি", num), param("ঘাত", num)), DoPow),
+			native(sig("সংখ্যা", param("মান", num, str, object.BOOL_OBJ)), ToNumber),
+			native(sig("দশমিক_সংখ্যা", param("মান", num, str, object.BOOL_OBJ)), ConvertToFloat),
+			native(sig("পুর্নসংখ্যা", param("মান", num, str, object.BOOL_OBJ)), ConvertToInt),
+			native(sig("এলোমেলো_সংখ্যা", param("সীমা", num)), GenerateRandom),
+		),
+		Values: map[string]object.Obj{
+			"ধ্রুবক_পাই": object.MakeFloatNumber(math.Pi),
+			"ধ্রুবক_ই":   object.MakeFloatNumber(math.E),
+		},
+		Source: mathSource,
+	})
+
+	RegisterModule(&Module{
+		Name: "array",
+		Builtins: aliased(builtins(
+			native(sig("দৈর্ঘ্য", param("মান", array, str)), Length),
+			native(sig("প্রথম", param("তালিকা", array)), ArrayFirst),
+			native(sig("অন্তিম", param("তালিকা", array)), ArrayLast),
+			native(sig("বাকি", param("তালিকা", array)), ArrayRest),
+			native(sig("যোগ_করো", param("তালিকা", array), param("মান")), ArrayPush),
+			native(sig("শেষের_মুছুন", param("তালিকা", array)), ArrayPopWithoutIndex),
+			native(sig("সুচকে_মুছুন", param("তালিকা", array), param("সূচক", num)), ArrayPopIndex),
+			native(sig("যুক্ত", param("তালিকা", array), param("অন্য_তালিকা", array)), JoinArrays),
+			native(sig("নিবেশ", param("তালিকা", array), param("মান"), param("সূচক", num)), InsertToArray),
+			native(sig("যেমন_আছে_তেমন_নিবেশ", param("তালিকা", array), param("মান"), param("সূচক", num)), InsertToArrayAsIs),
+			withEnv(sig("রূপান্তর", param("তালিকা", array), param("কাজ", function...)), ArrayMap),
+			withEnv(sig("ছাঁকো", param("তালিকা", array), param("কাজ", function...)), ArrayFilter),
+			withEnv(sig("জমাও", param("তালিকা", array), param("কাজ", function...), param("শুরু")), ArrayReduce),
+			withEnv(sig("খোঁজো", param("তালিকা", array), param("কাজ", function...)), ArrayFind),
+			withEnv(sig("কোনোটি", param("তালিকা", array), param("কাজ", function...)), ArrayAny),
+			withEnv(sig("সবকটি", param("তালিকা", array), param("কাজ", function...)), ArrayAll),
+			withEnv(variadic("সাজাও", param("তালিকা", array), param("তুলনা_বা_বিকল্প", append(function, hash)...)), ArraySort),
+		), map[string]string{
+			"দৈর্ঘ্য":             "len",
+			"প্রথম":               "first",
+			"অন্তিম":              "last",
+			"বাকি":                "rest",
+			"যোগ_করো":             "push",
+			"শেষের_মুছুন":         "pop",
+			"সুচকে_মুছুন":         "pop_at",
+			"যুক্ত":               "concat",
+			"নিবেশ":               "insert",
+			"যেমন_আছে_তেমন_নিবেশ": "insert_as_is",
+			"রূপান্তর":            "map",
+			"ছাঁকো":               "filter",
+			"জমাও":                "reduce",
+			"খোঁজো":               "find",
+			"কোনোটি":              "any",
+			"সবকটি":               "all",
+			"সাজাও":               "sort",
+		}),
+	})
+
+	RegisterModule(&Module{
+		Name: "date",
+		Builtins: builtins(
+			nativeNoArg("এখন", TimeNow),
+			nativeNoArg("আজ", DateNow),
+			nativeNoArg("ইউনিক্স_সময়", func() object.Obj { return UnixTimeFunc(nil) }),
+			nativeNoArg("আইএসও_সময়", func() object.Obj { return UtcDateISO(nil) }),
+			native(sig("সময়_ফরমেট_স্থানীয়", param("ফরমেট", str)), FormatTimeLocal),
+			native(sig("সময়_ফরমেট_গ্রিনিচ", param("ফরমেট", str)), FormatTimeUTC),
+		),
+	})
+
+	RegisterModule(&Module{
+		Name: "file",
+		Builtins: builtins(
+			nativeRt(sig("পড়ো", param("ঠিকানা", str)), ReadFile),
+			nativeRt(sig("আছে_কি", param("ঠিকানা", str)), FileDirExists),
+			nativeRt(sig("ফাঁকা_তৈরি", param("ঠিকানা", str)), CreateEmptyFile),
+			nativeRt(sig("লেখো", param("ঠিকানা", str), param("তথ্য")), WriteToFile),
+			nativeRt(sig("মুছুন", param("ঠিকানা", str)), DeletePath),
+			nativeRt(sig("নাম_পরিবর্তন", param("ঠিকানা", str), param("নতুন_নাম", str)), RenameFile),
+			nativeRt(sig("ফাইল_কি", param("ঠিকানা", str)), IsAFile),
+			nativeRt(sig("ফোল্ডার_কি", param("ঠিকানা", str)), IsADir),
+			nativeRt(sig("লাইন_যোগ", param("ঠিকানা", str), param("লাইন", str)), AppendLineToFile),
+			nativeRt(sig("ফাইলের_তালিকা", param("ঠিকানা", str)), ListDir),
+		),
+	})
+
+	RegisterModule(&Module{
+		Name: "std",
+		Builtins: builtins(
+			requires(stdin, nativeRt(sig("লাইন_পড়ো", param("বার্তা", str)), ReadLine)),
+			native(sig("গোলযোগ", param("বার্তা", str)), ReturnErrorString),
+			native(sig("প্রকার", param("মান")), GetType),
+		),
+	})
+
+	RegisterModule(&Module{
+		Name: "string",
+		Builtins: aliased(builtins(
+			native(sig("খণ্ড", param("লেখা", str), param("বিভাজক", str)), SplitString),
+			native(sig("যোগ", param("তালিকা", array), param("বিভাজক", str)), JoinAsString),
+			nativeRt(sig("পরিবর্তন", param("মান")), ToString),
+			native(sig("বাংলা_অঙ্ক", param("মান", num, str)), ToBengaliDigits),
+			native(sig("ইংরেজি_অঙ্ক", param("মান", num, str)), ToASCIIDigits),
+			native(sig("আছে_কি", param("লেখা", str), param("অংশ", str)), Contains),
+			native(sig("অবস্থান", param("লেখা", str), param("অংশ", str)), IndexOf),
+			native(sig("শুরুতে_আছে", param("লেখা", str), param("অংশ", str)), StartsWith),
+			native(sig("শেষে_আছে", param("লেখা", str), param("অংশ", str)), EndsWith),
+			native(sig("প্রতিস্থাপন", param("লেখা", str), param("পুরানো", str), param("নতুন", str)), Replace),
+			native(sig("সব_প্রতিস্থাপন", param("লেখা", str), param("পুরানো", str), param("নতুন", str)), ReplaceAll),
+			native(variadic("ছাঁটো", param("লেখা", str), param("চিহ্ন", str)), Trim),
+			native(variadic("বাঁয়ে_ছাঁটো", param("লেখা", str), param("চিহ্ন", str)), TrimLeft),
+			native(variadic("ডানে_ছাঁটো", param("লেখা", str), param("চিহ্ন", str)), TrimRight),
+			native(sig("বড়_হাতের", param("লেখা", str)), ToUpper),
+			native(sig("ছোট_হাতের", param("লেখা", str)), ToLower),
+			nativeRt(sig("পুনরাবৃত্তি", param("লেখা", str), param("বার", num)), Repeat),
+			nativeRt(variadic("বাঁয়ে_ভরাট", param("লেখা", str), param("দৈর্ঘ্য", num), param("ভরাট", str)), PadLeft),
+			nativeRt(variadic("ডানে_ভরাট", param("লেখা", str), param("দৈর্ঘ্য", num), param("ভরাট", str)), PadRight),
+			native(sig("উল্টো", param("লেখা", str)), ReverseString),
+			nativeRt(variadic("ফরম্যাট", param("ছাঁচ", str), param("মান")), Format),
+			nativeRt(variadic("বাংলা_ফরম্যাট", param("ছাঁচ", str), param("মান")), FormatBengali),
+			withEnv(sig("তুলনা", param("ক", num, str), param("খ", num, str)), CompareValues),
+		), map[string]string{
+			"খণ্ড":           "split",
+			"যোগ":            "join",
+			"পরিবর্তন":       "to_string",
+			"বাংলা_অঙ্ক":     "bengali_digits",
+			"ইংরেজি_অঙ্ক":    "ascii_digits",
+			"আছে_কি":         "contains",
+			"অবস্থান":        "index_of",
+			"শুরুতে_আছে":     "starts_with",
+			"শেষে_আছে":       "ends_with",
+			"প্রতিস্থাপন":    "replace",
+			"সব_প্রতিস্থাপন": "replace_all",
+			"ছাঁটো":          "trim",
+			"বাঁয়ে_ছাঁটো":    "trim_left",
+			"ডানে_ছাঁটো":     "trim_right",
+			"বড়_হাতের":       "upper",
+			"ছোট_হাতের":      "lower",
+			"পুনরাবৃত্তি":    "repeat",
+			"বাঁয়ে_ভরাট":     "pad_left",
+			"ডানে_ভরাট":      "pad_right",
+			"উল্টো":          "reverse",
+			"ফরম্যাট":        "format",
+			"বাংলা_ফরম্যাট":  "format_bn",
+			"তুলনা":          "compare",
+		}),
+	})
+
+	RegisterModule(&Module{
+		Name: "regex",
+		Builtins: aliased(builtins(
+			withRegex(sig("কম্পাইল", param("প্যাটার্ন", regex...)), CompileRegex),
+			withRegex(sig("মেলে", param("প্যাটার্ন", regex...), param("লেখা", str)), MatchRegex),
+			withRegex(sig("খোঁজো", param("প্যাটার্ন", regex...), param("লেখা", str)), FindRegex),
+			withRegex(sig("সব_খোঁজো", param("প্যাটার্ন", regex...), param("লেখা", str)), FindAllRegex),
+			withRegex(sig("দল", param("প্যাটার্ন", regex...), param("লেখা", str)), RegexGroups),
+			withRegex(sig("সব_দল", param("প্যাটার্ন", regex...), param("লেখা", str)), RegexAllGroups),
+			withRegex(sig("নামের_দল", param("প্যাটার্ন", regex...), param("লেখা", str)), RegexNamedGroups),
+			withRegex(sig("প্রতিস্থাপন", param("প্যাটার্ন", regex...), param("লেখা", str), param("বদল", append(function, str)...)), ReplaceRegex),
+			withRegex(sig("খণ্ড", param("প্যাটার্ন", regex...), param("লেখা", str)), SplitRegex),
+		), map[string]string{
+			"কম্পাইল":     "compile",
+			"মেলে":        "match",
+			"খোঁজো":       "find",
+			"সব_খোঁজো":    "find_all",
+			"দল":          "groups",
+			"সব_দল":       "groups_all",
+			"নামের_দল":    "named_groups",
+			"প্রতিস্থাপন": "replace",
+			"খণ্ড":        "split",
+		}),
+	})
+
+	RegisterModule(&Module{
+		Name: "json",
+		Builtins: aliased(builtins(
+			withEnv(sig("পড়ো", param("লেখা", str)), DecodeJSON),
+			withEnv(variadic("লেখো", param("মান"), param("বিকল্প", hash)), EncodeJSON),
+		), map[string]string{
+			"পড়ো":  "decode",
+			"লেখো": "encode",
+		}),
+	})
+
+	RegisterModule(&Module{
+		Name: "csv",
+		Builtins: aliased(builtins(
+			withEnv(variadic("পড়ো", param("লেখা", str), param("বিকল্প", hash)), ReadCSV),
+			withEnv(variadic("ফাইল_পড়ো", param("ঠিকানা", str), param("বিকল্প", hash)), ReadCSVFile),
+			withEnv(variadic("লেখো", param("সারি", array), param("বিকল্প", hash)), WriteCSV),
+			withEnv(variadic("ফাইল_লেখো", param("ঠিকানা", str), param("সারি", array), param("বিকল্প", hash)), WriteCSVFile),
+		), map[string]string{
+			"পড়ো":       "read",
+			"ফাইল_পড়ো":  "read_file",
+			"লেখো":      "write",
+			"ফাইল_লেখো": "write_file",
+		}),
+	})
+
+	RegisterModule(&Module{
+		Name: "bangla",
+		Builtins: aliased(builtins(
+			native(sig("অক্ষর", param("লেখা", str)), Aksharas),
+			native(sig("যুক্তাক্ষর", param("লেখা", str)), Conjuncts),
+			native(sig("যুক্তাক্ষর_কি", param("লেখা", str)), IsConjunct),
+			native(sig("হসন্ত_আছে", param("লেখা", str)), HasHasanta),
+			native(sig("কার", param("লেখা", str)), VowelSigns),
+			native(sig("কার_মুছুন", param("লেখা", str)), StripVowelSigns),
+			native(sig("লিপি", param("লেখা", str)), ScriptOf),
+			native(sig("শব্দ", param("লেখা", str)), Words),
+			native(sig("বাক্য", param("লেখা", str)), Sentences),
+			native(sig("শব্দ_সংখ্যা", param("লেখা", str)), WordCount),
+			native(sig("বাক্য_সংখ্যা", param("লেখা", str)), SentenceCount),
+		), map[string]string{
+			"অক্ষর":         "aksharas",
+			"যুক্তাক্ষর":    "conjuncts",
+			"যুক্তাক্ষর_কি": "is_conjunct",
+			"হসন্ত_আছে":     "has_hasanta",
+			"কার":           "vowel_signs",
+			"কার_মুছুন":     "strip_vowel_signs",
+			"লিপি":          "script",
+			"শব্দ":          "words",
+			"বাক্য":         "sentences",
+			"শব্দ_সংখ্যা":   "word_count",
+			"বাক্য_সংখ্যা":  "sentence_count",
+		}),
+	})
+
+	RegisterModule(&Module{
+		Name: "sys",
+		Builtins: builtins(
+			requires(osInfo, nativeNoArg("ওএস", GetOS)),
+			requires(osInfo, nativeNoArg("ওএস_আর্চ", GetArch)),
+			requires(osInfo, nativeNoArg("ব্যাবহারকারী", GetUserName)),
+			requires(osInfo, nativeNoArg("ব্যাবহারকারী_ঘর", GetUserHomeDir)),
+		),
+	})
+}
@@ -0,0 +1,195 @@
+package stdlib
+
+import (
+	"regexp"
+
+	"go.cs.palashbauri.in/pankti/object"
+	"go.cs.palashbauri.in/pankti/token"
+)
+
+// The regex module uses the syntax of Go's regexp package. Every
+// function takes the pattern either as a string or as a regex made by
+// compile, which saves compiling the same pattern again and again.
+//
+// A `{` in a normal string starts an interpolation, so patterns with
+// quantifiers such as {3} are written as raw strings in backticks, as in
+// `[০-৯]{4}`, or with the brace escaped as \{
+
+// regex is the type of a pattern argument
+var regex = []object.ObjType{str, object.REGEX_OBJ}
+
+// regexArg returns the compiled pattern of the first argument, or an
+// error at the call of the builtin if the pattern is not valid
+func regexArg(eh *object.ErrorHelper, caller token.Token, args []object.Obj) (*regexp.Regexp, object.Obj) {
+	if r, ok := args[0].(*object.Regex); ok {
+		return r.Re, nil
+	}
+
+	pattern := stringArg(args, 0)
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, object.NewErr(caller, eh, true, eh.Msg("INVALID_REGEX"), pattern, err.Error())
+	}
+
+	return re, nil
+}
+
+// withRegex makes a builtin of a function which gets the compiled
+// pattern and the rest of the arguments
+func withRegex(
+	sig *object.Signature,
+	fn func(*object.ErrorHelper, *object.EnvMap, token.Token, *regexp.Regexp, []object.Obj) object.Obj,
+) *object.Builtin {
+	return withEnv(sig, func(eh *object.ErrorHelper, env *object.EnvMap, caller token.Token, args []object.Obj) object.Obj {
+		re, err := regexArg(eh, caller, args)
+		if err != nil {
+			return err
+		}
+
+		return fn(eh, env, caller, re, args[1:])
+	})
+}
+
+func CompileRegex(_ *object.ErrorHelper, _ *object.EnvMap, caller token.Token, re *regexp.Regexp, _ []object.Obj) object.Obj {
+	return &object.Regex{Re: re, Token: caller}
+}
+
+// MatchRegex tells if the pattern matches any part of the string
+func MatchRegex(_ *object.ErrorHelper, _ *object.EnvMap, _ token.Token, re *regexp.Regexp, args []object.Obj) object.Obj {
+	return &object.Boolean{Value: re.MatchString(stringArg(args, 0))}
+}
+
+// FindRegex returns the first match in the string, or nil if there is
+// none
+func FindRegex(_ *object.ErrorHelper, _ *object.EnvMap, _ token.Token, re *regexp.Regexp, args []object.Obj) object.Obj {
+	s := stringArg(args, 0)
+	loc := re.FindStringIndex(s)
+	if loc == nil {
+		return &object.Null{}
+	}
+
+	return &object.String{Value: s[loc[0]:loc[1]]}
+}
+
+// FindAllRegex returns all the matches in the string
+func FindAllRegex(_ *object.ErrorHelper, _ *object.EnvMap, _ token.Token, re *regexp.Regexp, args []object.Obj) object.Obj {
+	result := &object.Array{Elms: []object.Obj{}}
+	for _, m := range re.FindAllString(stringArg(args, 0), -1) {
+		result.Elms = append(result.Elms, &object.String{Value: m})
+	}
+
+	return result
+}
+
+// RegexGroups returns the first match followed by its groups, or nil if
+// there is no match. A group which took no part in the match is nil
+func RegexGroups(_ *object.ErrorHelper, _ *object.EnvMap, _ token.Token, re *regexp.Regexp, args []object.Obj) object.Obj {
+	s := stringArg(args, 0)
+	loc := re.FindStringSubmatchIndex(s)
+	if loc == nil {
+		return &object.Null{}
+	}
+
+	return groups(s, loc)
+}
+
+// RegexAllGroups is like RegexGroups but for all the matches
+func RegexAllGroups(_ *object.ErrorHelper, _ *object.EnvMap, _ token.Token, re *regexp.Regexp, args []object.Obj) object.Obj {
+	s := stringArg(args, 0)
+	result := &object.Array{Elms: []object.Obj{}}
+	for _, loc := range re.FindAllStringSubmatchIndex(s, -1) {
+		result.Elms = append(result.Elms, groups(s, loc))
+	}
+
+	return result
+}
+
+// RegexNamedGroups returns the named groups, such as `(?P<name>...)`, of
+// the first match as a hash of their names to their values, or nil if
+// there is no match. Go's regexp allows only ascii letters, digits and
+// `_` in the names of groups
+func RegexNamedGroups(_ *object.ErrorHelper, _ *object.EnvMap, _ token.Token, re *regexp.Regexp, args []object.Obj) object.Obj {
+	s := stringArg(args, 0)
+	loc := re.FindStringSubmatchIndex(s)
+	if loc == nil {
+		return &object.Null{}
+	}
+
+	all := groups(s, loc)
+	result := &object.Hash{Pairs: map[object.HashKey]object.HashPair{}}
+	for i, name := range re.SubexpNames() {
+		if len(name) == 0 {
+			continue
+		}
+
+		key := &object.String{Value: name}
+		result.Pairs[key.HashKey()] = object.HashPair{Key: key, Value: all.Elms[i]}
+	}
+
+	return result
+}
+
+// groups makes the array of a match and its groups from their locations
+// in s
+func groups(s string, loc []int) *object.Array {
+	result := &object.Array{Elms: []object.Obj{}}
+	for i := 0; i < len(loc); i += 2 {
+		if loc[i] < 0 {
+			result.Elms = append(result.Elms, &object.Null{})
+		} else {
+			result.Elms = append(result.Elms, &object.String{Value: s[loc[i]:loc[i+1]]})
+		}
+	}
+
+	return result
+}
+
+// ReplaceRegex replaces all the matches in the string. The replacement is
+// either a string, where `$1` or `${name}` stand for the groups, or a
+// function which is called with each match and returns its replacement
+func ReplaceRegex(eh *object.ErrorHelper, env *object.EnvMap, caller token.Token, re *regexp.Regexp, args []object.Obj) object.Obj {
+	s := stringArg(args, 0)
+	if repl, ok := args[1].(*object.String); ok {
+		return &object.String{Value: re.ReplaceAllString(s, repl.Value)}
+	}
+
+	rt := env.GetRuntime()
+	var failed object.Obj
+
+	result := re.ReplaceAllStringFunc(s, func(m string) string {
+		if failed != nil {
+			return m
+		}
+
+		r := rt.Call(eh, caller, args[1], []object.Obj{&object.String{Value: m}})
+		if object.IsErr(r) {
+			failed = r
+			return m
+		}
+
+		switch r := r.(type) {
+		case *object.Null:
+			return ""
+		case *object.String:
+			return r.Value
+		default:
+			return rt.Format(r)
+		}
+	})
+
+	if failed != nil {
+		return failed
+	}
+
+	return &object.String{Value: result}
+}
+
+// SplitRegex splits the string at every match
+func SplitRegex(_ *object.ErrorHelper, _ *object.EnvMap, _ token.Token, re *regexp.Regexp, args []object.Obj) object.Obj {
+	result := &object.Array{Elms: []object.Obj{}}
+	for _, part := range re.Split(stringArg(args, 0), -1) {
+		result.Elms = append(result.Elms, &object.String{Value: part})
+	}
+
+	return result
+}
@@ -1,51 +1,94 @@
 package stdlib
 
 import (
-	"embed"
-	"errors"
-	"io/fs"
-	"os"
-	"path/filepath"
+	"fmt"
 	"runtime"
+	"strings"
 
-	"go.cs.palashbauri.in/pankti/constants"
+	"go.cs.palashbauri.in/pankti/ast"
+	"go.cs.palashbauri.in/pankti/lexer"
+	"go.cs.palashbauri.in/pankti/object"
+	"go.cs.palashbauri.in/pankti/pack"
+	"go.cs.palashbauri.in/pankti/parser"
+	"go.cs.palashbauri.in/pankti/vfs"
 )
 
-//go:embed x
-var stdx embed.FS
-
 func IsAndroid() bool {
 	return runtime.GOOS == "android"
 }
 
-func GetStdLibFileSrc(path string) (string, bool) {
-	if filepath.IsAbs(path) {
-		if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
-			f, er := os.ReadFile(path)
-			if er == nil {
-				return string(f), true
-			}
+// GetStdLibFileSrc reads the source of a Pankti file included by path.
+// Standard library modules are native and are found with GetModule
+func GetStdLibFileSrc(fsys vfs.FS, path string) (string, bool) {
+	f, err := fsys.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+
+	return string(f), true
+}
+
+// LoadModuleSrc returns the source of a non-native module using the
+// module loader of the runtime, falling back to GetStdLibFileSrc. Files
+// are only read if the permissions of the runtime allow it
+func LoadModuleSrc(rt *object.Runtime, name string) (string, error) {
+	if rt == nil {
+		rt = object.DefaultRuntime()
+	}
 
+	if rt.Loader != nil {
+		if src, ok := rt.Loader(name); ok {
+			return src, nil
 		}
-	} else {
-		cwd, _ := os.Getwd()
-		p := filepath.Join(cwd, path)
-		if _, err := os.Stat(p); !errors.Is(err, fs.ErrNotExist) {
-			f, er := os.ReadFile(p)
-			if er == nil {
-				return string(f), true
-			}
+
+		return "", fmt.Errorf(rt.Pack.Msg("MODULE_NOT_FOUND"), name)
+	}
+
+	path, err := rt.ResolvePath(name, false)
+	if err != nil {
+		return "", err
+	}
+
+	if src, ok := GetStdLibFileSrc(rt.FileSystem(), path); ok {
+		return src, nil
+	}
+
+	return "", fmt.Errorf(rt.Pack.Msg("MODULE_NOT_FOUND"), name)
+}
+
+// ParseModule parses the source of the module called name with the
+// keyword pack of the runtime, which can be nil. The errors found in the
+// source are returned together as one error
+func ParseModule(rt *object.Runtime, name, src string) (*ast.Program, error) {
+	var kw *pack.Pack
+	if rt != nil {
+		kw = rt.Pack
+	}
+
+	l := lexer.NewLexerWithPack(src, kw)
+	p := parser.NewParser(&l)
+	prog := p.ParseProg()
+
+	if len(p.GetErrors()) > 0 {
+		msgs := []string{}
+		for _, e := range p.GetErrors() {
+			msgs = append(msgs, e.String())
 		}
-		enName, ok := constants.GetStdName(path)
-		if ok {
-			stdpath := "x/" + enName
-			f, err := stdx.ReadFile(stdpath)
-			//fmt.Println(err.Error())
-			if err == nil {
-				return string(f), true
-			}
+
+		return nil, fmt.Errorf(kw.Msg("MODULE_PARSE_FAILED"), name, strings.Join(msgs, "\n"))
+	}
+
+	return prog, nil
+}
+
+// FindModule finds a module defined by the host of the runtime or a
+// standard library module
+func FindModule(rt *object.Runtime, name string) (*Module, bool) {
+	if rt != nil {
+		if members, ok := rt.HostModules[name]; ok {
+			return &Module{Name: name, Values: members}, true
 		}
 	}
 
-	return "", false
+	return GetModule(name)
 }
@@ -1,67 +1,320 @@
 package stdlib
 
 import (
+	"math"
+	"reflect"
+	"regexp"
 	"strings"
 
-	"go.cs.palashbauri.in/pankti/errs"
+	"go.cs.palashbauri.in/pankti/grapheme"
+	"go.cs.palashbauri.in/pankti/number"
 	"go.cs.palashbauri.in/pankti/object"
 )
 
 func SplitString(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	target, ok := getStringFromArgs(args[0])
+	target := stringArg(args, 0)
+	delim := stringArg(args, 1)
 
-	if !ok {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["NOT_ALL_STRING"])
+	var result object.Array
+
+	for _, item := range strings.Split(target, delim) {
+		result.Elms = append(result.Elms, &object.String{Value: item})
 	}
 
-	delim, ok2 := getStringFromArgs(args[1])
+	return &result
+
+}
+
+func JoinAsString(eh *object.ErrorHelper, args []object.Obj) object.Obj {
+	target := arrayArg(args, 0)
+	delim := stringArg(args, 1)
 
-	if !ok2 {
+	result := ""
 
-		return object.NewErr(args[1].GetToken(), eh, true, errs.Errs["NOT_ALL_STRING"])
+	for index, item := range target {
+		result += item.Inspect()
+		if (index + 1) != len(target) {
+			result += delim
+		}
 	}
 
-	var result object.Array
+	return &object.String{Value: result}
 
-	for _, item := range strings.Split(target, delim) {
-		result.Elms = append(result.Elms, &object.String{Value: item})
+}
+
+func ToString(_ *object.ErrorHelper, rt *object.Runtime, args []object.Obj) object.Obj {
+	return &object.String{Value: rt.Format(args[0])}
+}
+
+// ToBengaliDigits writes a number, or the digits of a string, with
+// bengali digits
+func ToBengaliDigits(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return &object.String{Value: number.ToBengaliDigits(args[0].Inspect())}
+}
+
+// ToASCIIDigits writes a number, or the digits of a string, with ascii
+// digits
+func ToASCIIDigits(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return &object.String{Value: number.ToASCIIDigits(args[0].Inspect())}
+}
+
+// stringArg returns the value of a string argument, which the signature
+// of the builtin has already checked
+func stringArg(args []object.Obj, i int) string {
+	return args[i].(*object.String).Value
+}
+
+func Contains(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return &object.Boolean{Value: strings.Contains(stringArg(args, 0), stringArg(args, 1))}
+}
+
+// IndexOf returns where a part first appears in a string, counted in
+// grapheme clusters like indexing, or -1 if it does not
+func IndexOf(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	s := stringArg(args, 0)
+	i := strings.Index(s, stringArg(args, 1))
+	if i < 0 {
+		return object.MakeIntNumber(-1)
 	}
 
-	return &result
+	return object.MakeIntNumber(int64(grapheme.Count(s[:i])))
+}
 
+func StartsWith(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return &object.Boolean{Value: strings.HasPrefix(stringArg(args, 0), stringArg(args, 1))}
 }
 
-func JoinAsString(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	target, ok := getArrayFromArg(args[0])
+func EndsWith(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return &object.Boolean{Value: strings.HasSuffix(stringArg(args, 0), stringArg(args, 1))}
+}
 
-	if !ok {
+// Replace replaces the first part of a string which matches
+func Replace(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return &object.String{Value: strings.Replace(stringArg(args, 0), stringArg(args, 1), stringArg(args, 2), 1)}
+}
+
+func ReplaceAll(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return &object.String{Value: strings.ReplaceAll(stringArg(args, 0), stringArg(args, 1), stringArg(args, 2))}
+}
+
+// trimmer makes a builtin which removes the characters given after the
+// string from one or both of its ends, or the white space if none are
+// given
+func trimmer(trim func(string, string) string) func(*object.ErrorHelper, []object.Obj) object.Obj {
+	return func(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+		cutset := ""
+		for i := range args[1:] {
+			cutset += stringArg(args, i+1)
+		}
+
+		if len(cutset) == 0 {
+			cutset = " \t\r\n\v\f"
+		}
 
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["NOT_ALL_ARE_LIST"])
+		return &object.String{Value: trim(stringArg(args, 0), cutset)}
 	}
+}
 
-	delim, ok2 := getStringFromArgs(args[1])
+var (
+	Trim      = trimmer(strings.Trim)
+	TrimLeft  = trimmer(strings.TrimLeft)
+	TrimRight = trimmer(strings.TrimRight)
+)
 
-	if !ok2 {
+// ToUpper writes the latin letters of a string in upper case; bengali
+// letters have no case and stay the same
+func ToUpper(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return &object.String{Value: strings.ToUpper(stringArg(args, 0))}
+}
 
-		return object.NewErr(args[1].GetToken(), eh, true, errs.Errs["NOT_ALL_STRING"])
+func ToLower(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return &object.String{Value: strings.ToLower(stringArg(args, 0))}
+}
+
+func Repeat(eh *object.ErrorHelper, rt *object.Runtime, args []object.Obj) object.Obj {
+	count, ok := object.AsIndex(args[1])
+	if !ok || count < 0 {
+		return object.NewErr(args[1].GetToken(), eh, true, eh.Msg("COUNT_MUST_BE_POSITIVE"))
 	}
 
-	result := ""
+	s := stringArg(args, 0)
+	if err := rt.CheckAlloc(timesSize(int64(len(s)), int64(count))); err != nil {
+		return object.WrapErr(err)
+	}
 
-	for index, item := range target {
-		result += item.Inspect()
-		if (index + 1) != len(target) {
-			result += delim
+	return &object.String{Value: strings.Repeat(s, int(count))}
+}
+
+// timesSize returns size*n, or -1 if it does not fit in an int64
+func timesSize(size, n int64) int64 {
+	if size > 0 && n > math.MaxInt64/size {
+		return -1
+	}
+
+	return size * n
+}
+
+// padder makes a builtin which fills a string at one end up to a length
+// in grapheme clusters, with the string given after the length or with
+// spaces
+func padder(left bool) func(*object.ErrorHelper, *object.Runtime, []object.Obj) object.Obj {
+	return func(eh *object.ErrorHelper, rt *object.Runtime, args []object.Obj) object.Obj {
+		s := stringArg(args, 0)
+		width, ok := object.AsIndex(args[1])
+		if !ok {
+			return object.NewErr(args[1].GetToken(), eh, true, eh.Msg("COUNT_MUST_BE_POSITIVE"))
+		}
+
+		fill := []string{" "}
+		if len(args) > 2 {
+			fill = grapheme.Clusters(stringArg(args, 2))
+		}
+
+		n := int(width) - grapheme.Count(s)
+		if n <= 0 || len(fill) == 0 {
+			return &object.String{Value: s}
+		}
+
+		// the padding has n/len(fill) whole fills and the start of one more
+		size := timesSize(int64(len(strings.Join(fill, ""))), int64(n/len(fill)))
+		if size >= 0 {
+			size += int64(len(strings.Join(fill[:n%len(fill)], ""))) + int64(len(s))
+		}
+
+		if err := rt.CheckAlloc(size); err != nil {
+			return object.WrapErr(err)
+		}
+
+		pad := strings.Builder{}
+		for i := 0; i < n; i++ {
+			pad.WriteString(fill[i%len(fill)])
+		}
+
+		if left {
+			return &object.String{Value: pad.String() + s}
 		}
+
+		return &object.String{Value: s + pad.String()}
 	}
+}
 
-	return &object.String{Value: result}
+var (
+	PadLeft  = padder(true)
+	PadRight = padder(false)
+)
+
+// ReverseString reverses a string by grapheme clusters, so that vowel
+// signs and conjuncts stay with their letters
+func ReverseString(_ *object.ErrorHelper, args []object.Obj) object.Obj {
+	return &object.String{Value: grapheme.Reverse(stringArg(args, 0))}
+}
 
+// formatter makes a printf style builtin, such as
+// ফরম্যাট("%s পেয়েছে %.1f%%", নাম, নম্বর), which writes numbers with the
+// digits of the runtime, or always with bengali digits
+func formatter(bengali bool) func(*object.ErrorHelper, *object.Runtime, []object.Obj) object.Obj {
+	return func(eh *object.ErrorHelper, rt *object.Runtime, args []object.Obj) object.Obj {
+		digits := rt.Digits
+		if bengali {
+			digits = number.BengaliDigits
+		}
+
+		format := stringArg(args, 0)
+		values := []interface{}{}
+		for _, arg := range args[1:] {
+			values = append(values, formatValue(arg, digits))
+		}
+
+		if !formatFits(format, values) {
+			return object.NewErr(args[0].GetToken(), eh, true, eh.Msg("FORMAT_MISMATCH"), format)
+		}
+
+		return &object.String{Value: object.Sprintf(digits, format, values...)}
+	}
 }
 
-func ToString(_ *object.ErrorHelper, args []object.Obj) object.Obj {
-	//if args[0].Type() != object.NULL_OBJ{
-	return &object.String{Value: args[0].Inspect()}
-	//}
+// verbsOf are the printf verbs which can format each kind of value
+var verbsOf = map[reflect.Kind]string{
+	reflect.Int64:   "bcdoOqxXUv",
+	reflect.Float64: "beEfFgGxXv",
+	reflect.String:  "sqxXv",
+	reflect.Bool:    "tv",
+}
+
+// formatFits reports if the verbs of a printf style format match the
+// values one to one, such as %d with a whole number. Argument indexes and
+// widths given as arguments, as in %[1]d or %*d, are not supported
+func formatFits(format string, values []interface{}) bool {
+	rs := []rune(format)
+	n := 0
+
+	for i := 0; i < len(rs); i++ {
+		if rs[i] != '%' {
+			continue
+		}
+
+		// flags, width and precision
+		i++
+		for i < len(rs) && strings.ContainsRune("+-# 0123456789.", rs[i]) {
+			i++
+		}
+
+		if i == len(rs) || rs[i] == '*' || rs[i] == '[' {
+			return false
+		}
+
+		if rs[i] == '%' {
+			continue
+		}
+
+		if n == len(values) || !strings.ContainsRune(verbsOf[reflect.TypeOf(values[n]).Kind()], rs[i]) {
+			return false
+		}
+		n++
+	}
+
+	return n == len(values)
+}
+
+var (
+	Format        = formatter(false)
+	FormatBengali = formatter(true)
+)
+
+// formatValue returns the Go value of a value for fmt
+func formatValue(o object.Obj, digits number.Digits) interface{} {
+	switch o := o.(type) {
+	case *object.Number:
+		if o.Value.IsInt {
+			i, _ := number.GetAsInt(o.Value)
+			return i
+		}
+
+		f, _ := o.Value.GetAsFloat()
+		return f
+	case *object.String:
+		return o.Value
+	case *object.Boolean:
+		return o.Value
+	default:
+		return object.Format(o, digits)
+	}
+}
+
+var numberText = regexp.MustCompile(`^[-+]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][-+]?[0-9]+)?$`)
+
+// numberLike returns the number written in s, with ascii or bengali
+// digits and maybe white space around it
+func numberLike(s string) (*object.Number, bool) {
+	digits := number.ToASCIIDigits(strings.TrimSpace(s))
+	if !numberText.MatchString(digits) {
+		return nil, false
+	}
+
+	n := number.Number{}
+	if !n.SetValue(strings.TrimPrefix(digits, "+")) {
+		return nil, false
+	}
 
+	return &object.Number{Value: n, IsInt: n.IsInt}, true
 }
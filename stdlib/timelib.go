@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"time"
 
-	"go.cs.palashbauri.in/pankti/errs"
 	"go.cs.palashbauri.in/pankti/object"
 )
 
@@ -38,11 +37,7 @@ func dateFormat(_ string) string {
 }
 
 func FormatTimeLocal(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	f, ok := getStringFromArgs(args[0])
-
-	if !ok {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["NOT_ALL_STRING"])
-	}
+	f := stringArg(args, 0)
 
 	return &object.String{
 		Value: time.Now().Local().Format(dateFormat(f)),
@@ -50,11 +45,7 @@ func FormatTimeLocal(eh *object.ErrorHelper, args []object.Obj) object.Obj {
 }
 
 func FormatTimeUTC(eh *object.ErrorHelper, args []object.Obj) object.Obj {
-	f, ok := getStringFromArgs(args[0])
-
-	if !ok {
-		return object.NewErr(args[0].GetToken(), eh, true, errs.Errs["NOT_ALL_STRING"])
-	}
+	f := stringArg(args, 0)
 
 	return &object.String{
 		Value: time.Now().UTC().Format(dateFormat(f)),
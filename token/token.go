@@ -1,12 +1,47 @@
 package token
 
+import "golang.org/x/text/unicode/norm"
+
 type TokenType string
 
 type Token struct {
 	Type    TokenType
 	Literal string
-	LineNo  int
-	Column  int
+	// where the token starts; the same as Span.Start.Line and
+	// Span.Start.Column
+	LineNo int
+	Column int
+	// the exact range of the source code of the token, including the
+	// quotes of strings and the original digits of numbers
+	Span Span
+}
+
+// Pos is a position in the source code
+type Pos struct {
+	// byte offset, starting from 0
+	Offset int
+	// line number, starting from 1
+	Line int
+	// column in runes, starting from 1
+	Column int
+}
+
+// Span is the part of the source code from Start up to, but not
+// including, End
+type Span struct {
+	Start Pos
+	End   Pos
+}
+
+// IsZero reports if the span is not known, such as for tokens which are
+// not read from the source code
+func (s Span) IsZero() bool {
+	return s == Span{}
+}
+
+// To returns the span from the start of s to the end of other
+func (s Span) To(other Span) Span {
+	return Span{Start: s.Start, End: other.End}
 }
 
 const (
@@ -17,6 +52,11 @@ const (
 	PLUS    = "+"
 
 	STRING = "STRING"
+	// parts of a string with expressions in it, such as "ক {খ} গ {ঘ}":
+	// the head `"ক {`, the middle `} গ {` and the tail `}"`
+	STRING_HEAD = "STRING_HEAD"
+	STRING_MID  = "STRING_MID"
+	STRING_TAIL = "STRING_TAIL"
 	// Identifier token
 	IDENT = "IDENT"
 
@@ -36,6 +76,8 @@ const (
 	INCLUDE = "INCLUDE"
 
 	COMMENT = "COMMENT"
+	// `##` line before a definition, which documents it
+	DOC_COMMENT = "DOC_COMMENT"
 
 	//Equal = sign; for assignment
 	EQ = "="
@@ -153,6 +195,20 @@ var Keywords = map[string]TokenType{
 	"বা":       OR,
 }
 
+func init() {
+	// identifiers are looked up in NFC, which writes the nukta letters
+	// such as য় as two characters, so the keywords must be in NFC as well
+	for kw, t := range Keywords {
+		Keywords[Normalize(kw)] = t
+	}
+}
+
+// Normalize returns the NFC form of an identifier, so that names which
+// look the same but were typed with different keyboards are the same
+func Normalize(ident string) string {
+	return norm.NFC.String(ident)
+}
+
 func LookupIdent(ident string) TokenType {
 	if tok, ok := Keywords[ident]; ok {
 		return tok
@@ -0,0 +1,232 @@
+package vfs
+
+import (
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mem is a filesystem kept in memory. Its root is "/", which is also the
+// directory relative names are resolved against. It is safe to use from
+// multiple goroutines
+type Mem struct {
+	mu    sync.RWMutex
+	files map[string]*memFile
+}
+
+type memFile struct {
+	data    []byte
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+// NewMem returns an empty filesystem. Files can be added before giving
+// it to an interpreter with WriteFile, or all at once with NewMemFrom
+func NewMem() *Mem {
+	return &Mem{files: map[string]*memFile{
+		"/": {mode: fs.ModeDir | 0755, modTime: time.Now()},
+	}}
+}
+
+// NewMemFrom returns a filesystem which has the files given as name and
+// content pairs
+func NewMemFrom(files map[string]string) *Mem {
+	m := NewMem()
+	for name, content := range files {
+		m.WriteFile(name, []byte(content), 0644)
+	}
+
+	return m
+}
+
+func clean(name string) string {
+	return path.Clean("/" + filepath.ToSlash(name))
+}
+
+func memErr(op, name string, err error) error {
+	return &fs.PathError{Op: op, Path: name, Err: err}
+}
+
+func (m *Mem) Stat(name string) (fs.FileInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	p := clean(name)
+	f, ok := m.files[p]
+	if !ok {
+		return nil, memErr("stat", name, fs.ErrNotExist)
+	}
+
+	return &memInfo{name: path.Base(p), f: f}, nil
+}
+
+func (m *Mem) ReadFile(name string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	f, ok := m.files[clean(name)]
+	switch {
+	case !ok:
+		return nil, memErr("open", name, fs.ErrNotExist)
+	case f.mode.IsDir():
+		return nil, memErr("read", name, fs.ErrInvalid)
+	}
+
+	return append([]byte{}, f.data...), nil
+}
+
+// WriteFile also creates the missing parent directories of the file, so
+// a filesystem can be filled without creating the directories first
+func (m *Mem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p := clean(name)
+	if f, ok := m.files[p]; ok && f.mode.IsDir() {
+		return memErr("open", name, fs.ErrInvalid)
+	}
+
+	if err := m.mkdirAll(path.Dir(p)); err != nil {
+		return memErr("open", name, err)
+	}
+
+	m.files[p] = &memFile{
+		data:    append([]byte{}, data...),
+		mode:    perm.Perm(),
+		modTime: time.Now(),
+	}
+
+	return nil
+}
+
+// MkdirAll creates a directory with all of its missing parents
+func (m *Mem) MkdirAll(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.mkdirAll(clean(name)); err != nil {
+		return memErr("mkdir", name, err)
+	}
+
+	return nil
+}
+
+func (m *Mem) mkdirAll(p string) error {
+	if f, ok := m.files[p]; ok {
+		if !f.mode.IsDir() {
+			return fs.ErrExist
+		}
+
+		return nil
+	}
+
+	if err := m.mkdirAll(path.Dir(p)); err != nil {
+		return err
+	}
+
+	m.files[p] = &memFile{mode: fs.ModeDir | 0755, modTime: time.Now()}
+	return nil
+}
+
+func (m *Mem) AppendFile(name string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, ok := m.files[clean(name)]
+	switch {
+	case !ok:
+		return memErr("open", name, fs.ErrNotExist)
+	case f.mode.IsDir():
+		return memErr("write", name, fs.ErrInvalid)
+	}
+
+	f.data = append(f.data, data...)
+	f.modTime = time.Now()
+	return nil
+}
+
+func (m *Mem) Rename(oldname, newname string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	from, to := clean(oldname), clean(newname)
+	if _, ok := m.files[from]; !ok || from == "/" {
+		return memErr("rename", oldname, fs.ErrNotExist)
+	}
+
+	if _, ok := m.files[path.Dir(to)]; !ok {
+		return memErr("rename", newname, fs.ErrNotExist)
+	}
+
+	if to == from || strings.HasPrefix(to, from+"/") {
+		return memErr("rename", newname, fs.ErrInvalid)
+	}
+
+	for p, f := range m.files {
+		if p == from || strings.HasPrefix(p, from+"/") {
+			delete(m.files, p)
+			m.files[to+strings.TrimPrefix(p, from)] = f
+		}
+	}
+
+	return nil
+}
+
+// RemoveAll does nothing if name does not exist, like os.RemoveAll
+func (m *Mem) RemoveAll(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p := clean(name)
+	if p == "/" {
+		return memErr("remove", name, fs.ErrInvalid)
+	}
+
+	for fp := range m.files {
+		if fp == p || strings.HasPrefix(fp, p+"/") {
+			delete(m.files, fp)
+		}
+	}
+
+	return nil
+}
+
+func (m *Mem) ReadDir(name string) ([]fs.DirEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	p := clean(name)
+	f, ok := m.files[p]
+	switch {
+	case !ok:
+		return nil, memErr("open", name, fs.ErrNotExist)
+	case !f.mode.IsDir():
+		return nil, memErr("readdir", name, fs.ErrInvalid)
+	}
+
+	entries := []fs.DirEntry{}
+	for fp, f := range m.files {
+		if fp != "/" && path.Dir(fp) == p {
+			entries = append(entries, fs.FileInfoToDirEntry(&memInfo{name: path.Base(fp), f: f}))
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+type memInfo struct {
+	name string
+	f    *memFile
+}
+
+func (i *memInfo) Name() string       { return i.name }
+func (i *memInfo) Size() int64        { return int64(len(i.f.data)) }
+func (i *memInfo) Mode() fs.FileMode  { return i.f.mode }
+func (i *memInfo) ModTime() time.Time { return i.f.modTime }
+func (i *memInfo) IsDir() bool        { return i.f.mode.IsDir() }
+func (i *memInfo) Sys() any           { return nil }
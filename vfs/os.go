@@ -0,0 +1,65 @@
+package vfs
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// OS is the filesystem of the operating system
+type OS struct{}
+
+// IsOS tells if fsys is the filesystem of the operating system, given
+// either as OS or as *OS
+func IsOS(fsys FS) bool {
+	switch fsys.(type) {
+	case OS, *OS:
+		return true
+	}
+
+	return false
+}
+
+func (OS) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (OS) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (OS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+func (OS) AppendFile(name string, data []byte) error {
+	f, err := os.OpenFile(name, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func (OS) Rename(oldname, newname string) error {
+	return os.Rename(oldname, newname)
+}
+
+func (OS) RemoveAll(name string) error {
+	return os.RemoveAll(name)
+}
+
+func (OS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+// EvalSymlinks returns the path name points to after following symbolic
+// links
+func (OS) EvalSymlinks(name string) (string, error) {
+	return filepath.EvalSymlinks(name)
+}
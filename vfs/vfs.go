@@ -0,0 +1,95 @@
+// Package vfs is the filesystem used by the file module and by `anoyon`
+// to load modules. OS uses the real filesystem and Mem keeps the files in
+// memory, for hosts which have no filesystem (such as wasm) or should
+// not touch it.
+package vfs
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+)
+
+// FS is a writable filesystem. Names are paths in the style of the
+// operating system and relative names are relative to the current
+// directory of the filesystem
+type FS interface {
+	Stat(name string) (fs.FileInfo, error)
+	ReadFile(name string) ([]byte, error)
+	// WriteFile creates the file if it does not exist and replaces its
+	// content otherwise
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	// AppendFile adds data to the end of an existing file
+	AppendFile(name string, data []byte) error
+	Rename(oldname, newname string) error
+	// RemoveAll removes a file, or a directory with everything inside it
+	RemoveAll(name string) error
+	// ReadDir returns the entries of a directory sorted by their names
+	ReadDir(name string) ([]fs.DirEntry, error)
+}
+
+// SymlinkFS is implemented by filesystems which have symbolic links, so
+// that a path can be checked for where it really leads
+type SymlinkFS interface {
+	FS
+	EvalSymlinks(name string) (string, error)
+}
+
+// WalkFunc is called by Walk for every file and directory
+type WalkFunc func(path string, info fs.FileInfo, err error) error
+
+// Walk visits root and everything inside it in lexical order, like
+// filepath.Walk but on any FS
+func Walk(fsys FS, root string, fn WalkFunc) error {
+	info, err := fsys.Stat(root)
+	if err != nil {
+		err = fn(root, nil, err)
+	} else {
+		err = walk(fsys, root, info, fn)
+	}
+
+	if err == filepath.SkipDir {
+		return nil
+	}
+
+	return err
+}
+
+func walk(fsys FS, path string, info fs.FileInfo, fn WalkFunc) error {
+	if !info.IsDir() {
+		return fn(path, info, nil)
+	}
+
+	entries, err := fsys.ReadDir(path)
+	err1 := fn(path, info, err)
+	if err != nil || err1 != nil {
+		return err1
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	for _, entry := range entries {
+		name := filepath.Join(path, entry.Name())
+
+		info, err := entry.Info()
+		if err != nil {
+			if err := fn(name, info, err); err != nil && err != filepath.SkipDir {
+				return err
+			}
+			continue
+		}
+
+		if err := walk(fsys, name, info, fn); err != nil {
+			if err != filepath.SkipDir {
+				return err
+			}
+
+			// skipping a file skips the rest of its directory
+			if !info.IsDir() {
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package vfs
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMem(t *testing.T) {
+	m := NewMemFrom(map[string]string{
+		"ক/খ.txt": "খ",
+		"/গ.txt":  "গ",
+	})
+
+	if data, err := m.ReadFile("/ক/খ.txt"); err != nil || string(data) != "খ" {
+		t.Errorf("ReadFile -> got %q, %v", data, err)
+	}
+
+	if info, err := m.Stat("ক"); err != nil || !info.IsDir() {
+		t.Errorf("parent directory was not created; got %v, %v", info, err)
+	}
+
+	if err := m.AppendFile("গ.txt", []byte("ঘ")); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := m.ReadFile("গ.txt"); string(data) != "গঘ" {
+		t.Errorf("AppendFile -> got %q", data)
+	}
+
+	if err := m.AppendFile("নেই.txt", nil); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("AppendFile on missing file -> got %v", err)
+	}
+
+	if err := m.Rename("ক", "চ"); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := m.ReadFile("চ/খ.txt"); err != nil || string(data) != "খ" {
+		t.Errorf("Rename did not move the directory content; got %q, %v", data, err)
+	}
+	if _, err := m.Stat("ক/খ.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Rename kept the old path; got %v", err)
+	}
+
+	if err := m.RemoveAll("চ"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Stat("চ/খ.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("RemoveAll kept the directory content; got %v", err)
+	}
+
+	if err := m.WriteFile("/", nil, 0644); err == nil {
+		t.Errorf("expected error for writing to a directory")
+	}
+}
+
+func TestWalk(t *testing.T) {
+	m := NewMemFrom(map[string]string{
+		"খ/গ.txt": "",
+		"ক.txt":   "",
+		"খ/ঘ/ঙ":   "",
+	})
+
+	paths := []string{}
+	err := Walk(m, "/", func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		paths = append(paths, filepath.ToSlash(path))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"/", "/ক.txt", "/খ", "/খ/গ.txt", "/খ/ঘ", "/খ/ঘ/ঙ"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected %v, got %v", expected, paths)
+	}
+}
+
+func TestOSWalk(t *testing.T) {
+	dir := t.TempDir()
+	if err := (OS{}).WriteFile(filepath.Join(dir, "ক.txt"), []byte("ক"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{}
+	filepath.Walk(dir, func(path string, _ fs.FileInfo, _ error) error {
+		want = append(want, path)
+		return nil
+	})
+
+	got := []string{}
+	Walk(OS{}, dir, func(path string, _ fs.FileInfo, _ error) error {
+		got = append(got, path)
+		return nil
+	})
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
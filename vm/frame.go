@@ -3,6 +3,7 @@ package vm
 import (
 	"go.cs.palashbauri.in/pankti/code"
 	"go.cs.palashbauri.in/pankti/object"
+	"go.cs.palashbauri.in/pankti/token"
 )
 
 type Frame struct {
@@ -23,3 +24,9 @@ func NewFrame(fn *object.Closure, bp int) *Frame {
 func (f *Frame) Instructions() code.Instructions {
 	return f.cl.Fn.Instructions
 }
+
+// token returns the source token of the instruction at ip, or an empty
+// token if it has none
+func (f *Frame) token(ip int) token.Token {
+	return f.cl.Fn.Tokens[ip]
+}
@@ -1,15 +1,22 @@
 package vm
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"go.cs.palashbauri.in/pankti/code"
 	"go.cs.palashbauri.in/pankti/compiler"
+	"go.cs.palashbauri.in/pankti/diag"
 	"go.cs.palashbauri.in/pankti/number"
 	"go.cs.palashbauri.in/pankti/object"
+	"go.cs.palashbauri.in/pankti/stdlib"
 	"go.cs.palashbauri.in/pankti/token"
 )
 
+// StackSize and MaxFrames are the sizes the stack and the call frames
+// start with; both grow when needed, so that only the call depth limit
+// of the runtime decides how deep functions can be nested
 const StackSize = 2048
 
 var True = &object.Boolean{Value: true}
@@ -28,10 +35,14 @@ type VM struct {
 	globals     []object.Obj
 	frames      []*Frame
 	framesIndex int
+
+	// used when calling builtin functions
+	env *object.EnvMap
+	eh  object.ErrorHelper
 }
 
 func NewVM(bc compiler.ByteCode) *VM {
-	mainFunc := &object.CompiledFunc{Instructions: bc.Instructions}
+	mainFunc := &object.CompiledFunc{Instructions: bc.Instructions, Tokens: bc.Tokens}
 	mainClosure := &object.Closure{Fn: mainFunc}
 	mainFrame := NewFrame(mainClosure, 0)
 	frames := make([]*Frame, MaxFrames)
@@ -44,14 +55,41 @@ func NewVM(bc compiler.ByteCode) *VM {
 		globals:     make([]object.Obj, GlobalsSize),
 		frames:      frames,
 		framesIndex: 1,
+		env:         object.NewEnvMap(),
 	}
 }
 
+// NewVMWithGlobals creates a vm which uses the globals of an earlier
+// run, for example in a REPL
+func NewVMWithGlobals(bc compiler.ByteCode, globals []object.Obj) *VM {
+	vm := NewVM(bc)
+	vm.globals = globals
+	return vm
+}
+
+// SetRuntime sets the runtime which is used by the builtin functions
+func (vm *VM) SetRuntime(rt *object.Runtime) {
+	vm.env.Runtime = rt
+	vm.eh.Digits = rt.Digits
+	vm.eh.Pack = rt.Pack
+}
+
+// SetSource sets the source code of the program, which the errors are
+// shown with; color colors them for a terminal
+func (vm *VM) SetSource(src string, color bool) {
+	vm.eh.Source = src
+	vm.eh.Color = color
+}
+
 func (vm *VM) currentFrame() *Frame {
 	return vm.frames[vm.framesIndex-1]
 }
 
 func (vm *VM) pushFrame(f *Frame) {
+	if vm.framesIndex == len(vm.frames) {
+		vm.frames = append(vm.frames, f)
+	}
+
 	vm.frames[vm.framesIndex] = f
 	vm.framesIndex++
 }
@@ -69,12 +107,34 @@ func (vm *VM) StackTop() object.Obj {
 	return vm.stack[vm.sp-1]
 }
 
-func (vm *VM) Run() error {
+func (vm *VM) Run() (err error) {
+	// a bug in the vm or in a builtin must not crash the host
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf(vm.env.GetRuntime().Pack.Msg("INTERNAL_ERROR"), r)
+		}
+	}()
+
+	// builtins call the functions they are given through the runtime
+	vm.env.GetRuntime().Caller = vm.callFunc
+
+	return vm.run(0)
+}
+
+// run executes instructions until the frame at index `until` becomes the
+// current frame, or until the main function ends
+func (vm *VM) run(until int) error {
 	var ip int
 	var ins code.Instructions
 	var op code.OpCode
 
-	for vm.currentFrame().ip < len(vm.currentFrame().Instructions())-1 {
+	rt := vm.env.GetRuntime()
+
+	for vm.framesIndex > until && vm.currentFrame().ip < len(vm.currentFrame().Instructions())-1 {
+		if err := rt.Step(); err != nil {
+			return err
+		}
+
 		vm.currentFrame().ip++
 		ip = vm.currentFrame().ip
 		ins = vm.currentFrame().Instructions()
@@ -88,32 +148,32 @@ func (vm *VM) Run() error {
 
 			err := vm.push(vm.constants[constIndex])
 			if err != nil {
-				return nil
+				return vm.errorAt(ip, err)
 			}
 		case code.OpAdd, code.OpSub, code.OpMul, code.OpDiv:
 			err := vm.exeBinaryOp(op)
 			if err != nil {
-				return nil
+				return vm.errorAt(ip, err)
 			}
 		case code.OpTrue:
 			if err := vm.push(True); err != nil {
-				return err
+				return vm.errorAt(ip, err)
 			}
 		case code.OpFalse:
 			if err := vm.push(False); err != nil {
-				return err
+				return vm.errorAt(ip, err)
 			}
 		case code.OpEqual, code.OpNotEqual, code.OpGT:
 			if err := vm.exeComparison(op); err != nil {
-				return err
+				return vm.errorAt(ip, err)
 			}
 		case code.OpBang:
 			if err := vm.exeBangOp(); err != nil {
-				return err
+				return vm.errorAt(ip, err)
 			}
 		case code.OpMinus:
 			if err := vm.exeMinusOp(); err != nil {
-				return err
+				return vm.errorAt(ip, err)
 			}
 		case code.OpJump:
 			pos := int(code.ReadUint16(ins[ip+1:]))
@@ -131,34 +191,40 @@ func (vm *VM) Run() error {
 			}
 		case code.OpNull:
 			if err := vm.push(Null); err != nil {
-				return err
+				return vm.errorAt(ip, err)
 			}
 		case code.OpSetGlobal:
 			gIndex := code.ReadUint16(ins[ip+1:])
 			vm.currentFrame().ip += 2
 			vm.globals[gIndex] = vm.pop()
 		case code.OpGetGlobal:
-			localId := code.ReadUint16(ins[ip+1:])
-			vm.currentFrame().ip += 2
-
-			f := vm.currentFrame()
-			if err := vm.push(vm.stack[f.basePointer+int(localId)]); err != nil {
-				return err
-			}
-
-			/*gIndex := code.ReadUint16(ins[ip+1:])
+			gIndex := code.ReadUint16(ins[ip+1:])
 			vm.currentFrame().ip += 2
 
 			if err := vm.push(vm.globals[gIndex]); err != nil {
-				return err
-			}*/
+				return vm.errorAt(ip, err)
+			}
 		case code.OpArray:
 			numElms := int(code.ReadUint16(ins[ip+1:]))
 			vm.currentFrame().ip += 2
 			arr := vm.buildArray(vm.sp-numElms, vm.sp)
 			vm.sp = vm.sp - numElms
+			if err := rt.Alloc(arr); err != nil {
+				return vm.errorAt(ip, err)
+			}
 			if err := vm.push(arr); err != nil {
-				return err
+				return vm.errorAt(ip, err)
+			}
+		case code.OpConcat:
+			numParts := int(code.ReadUint16(ins[ip+1:]))
+			vm.currentFrame().ip += 2
+			str := rt.Concat(vm.stack[vm.sp-numParts:vm.sp], vm.currentFrame().token(ip))
+			vm.sp = vm.sp - numParts
+			if err := rt.Alloc(str); err != nil {
+				return vm.errorAt(ip, err)
+			}
+			if err := vm.push(str); err != nil {
+				return vm.errorAt(ip, err)
 			}
 		case code.OpHash:
 			numElms := int(code.ReadUint16(ins[ip+1:]))
@@ -166,32 +232,44 @@ func (vm *VM) Run() error {
 			hash, err := vm.buildHash(vm.sp-numElms, vm.sp)
 
 			if err != nil {
-				return err
+				return vm.errorAt(ip, err)
 			}
 			vm.sp = vm.sp - numElms
+			if err := rt.Alloc(hash); err != nil {
+				return vm.errorAt(ip, err)
+			}
 			err = vm.push(hash)
 			if err != nil {
-				return err
+				return vm.errorAt(ip, err)
 			}
 		case code.OpIndex:
 			index := vm.pop()
 			left := vm.pop()
 
 			if err := vm.exeIndexExpr(left, index); err != nil {
-				return err
+				return vm.errorAt(ip, err)
+			}
+		case code.OpSlice:
+			end := vm.pop()
+			start := vm.pop()
+			left := vm.pop()
+
+			if err := vm.exeSliceExpr(left, start, end); err != nil {
+				return vm.errorAt(ip, err)
 			}
 		case code.OpCall:
 			numArgs := code.ReadUint8(ins[ip+1:])
+			caller := vm.currentFrame().token(ip)
 			vm.currentFrame().ip += 1
-			if err := vm.exeCall(int(numArgs)); err != nil {
-				return err
+			if err := vm.exeCall(int(numArgs), caller); err != nil {
+				return vm.errorAt(ip, err)
 			}
 		case code.OpClosure:
 			cIndex := code.ReadUint16(ins[ip+1:])
 			nf := code.ReadUint8(ins[ip+3:])
 			vm.currentFrame().ip += 3
 			if err := vm.pushClosure(int(cIndex), int(nf)); err != nil {
-				return err
+				return vm.errorAt(ip, err)
 			}
 			//			if err := vm.push
 
@@ -201,14 +279,14 @@ func (vm *VM) Run() error {
 			vm.sp = f.basePointer - 1
 			//vm.pop()
 			if err := vm.push(rvalue); err != nil {
-				return err
+				return vm.errorAt(ip, err)
 			}
 		case code.OpReturn:
 			f := vm.popFrame()
 			vm.sp = f.basePointer - 1
 			//vm.pop()
 			if err := vm.push(Null); err != nil {
-				return err
+				return vm.errorAt(ip, err)
 			}
 		case code.OpSetLocal:
 			localId := code.ReadUint8(ins[ip+1:])
@@ -221,7 +299,7 @@ func (vm *VM) Run() error {
 			f := vm.currentFrame()
 
 			if err := vm.push(vm.stack[f.basePointer+int(lindex)]); err != nil {
-				return err
+				return vm.errorAt(ip, err)
 			}
 		case code.OpGetFree:
 			fi := code.ReadUint8(ins[ip+1:])
@@ -229,12 +307,19 @@ func (vm *VM) Run() error {
 			cc := vm.currentFrame().cl
 
 			if err := vm.push(cc.Free[fi]); err != nil {
-				return err
+				return vm.errorAt(ip, err)
 			}
 		case code.OpCurrentClosure:
 			cc := vm.currentFrame().cl
 			if err := vm.push(cc); err != nil {
-				return err
+				return vm.errorAt(ip, err)
+			}
+		case code.OpGetBuiltin:
+			bIndex := code.ReadUint8(ins[ip+1:])
+			vm.currentFrame().ip += 1
+
+			if err := vm.push(stdlib.Builtins[bIndex].Builtin); err != nil {
+				return vm.errorAt(ip, err)
 			}
 		case code.OpPop:
 			vm.pop()
@@ -245,6 +330,27 @@ func (vm *VM) Run() error {
 	return nil
 }
 
+// errorAt shows err at the source of the instruction at ip of the current
+// frame, unless it is already an error of the program
+func (vm *VM) errorAt(ip int, err error) error {
+	var perr *object.Error
+	if errors.As(err, &perr) {
+		return err
+	}
+
+	// a limit is shown at the line only, as the evaluator does; the host
+	// can still tell it from an error of the program
+	var lerr *object.LimitError
+	isLimit := errors.As(err, &lerr)
+
+	perr = object.NewErr(vm.currentFrame().token(ip), &vm.eh, !isLimit, "%s", err.Error())
+	if isLimit {
+		perr.Err = err
+	}
+
+	return perr
+}
+
 func (vm *VM) pushClosure(ci int, nf int) error {
 	c := vm.constants[ci]
 	fn, ok := c.(*object.CompiledFunc)
@@ -261,29 +367,98 @@ func (vm *VM) pushClosure(ci int, nf int) error {
 	return vm.push(closure)
 }
 
-func (vm *VM) exeCall(n int) error {
+func (vm *VM) exeCall(n int, caller token.Token) error {
 	callee := vm.stack[vm.sp-1-n]
 	switch callee.Type() {
 	case object.CLOSURE_OBJ:
 		o := callee.(*object.Closure)
-		return vm.callClosure(o, n)
+		return vm.callClosure(o, n, caller)
+	case object.BUILTIN_OBJ:
+		o := callee.(*object.Builtin)
+		return vm.callBuiltin(o, n, caller)
 	default:
 		return fmt.Errorf("x+calling non-function")
 	}
 }
 
-func (vm *VM) callClosure(cl *object.Closure, numArgs int) error {
+// callFunc calls fn with args while a builtin is running, and returns
+// its result
+func (vm *VM) callFunc(eh *object.ErrorHelper, caller token.Token, fn object.Obj, args []object.Obj) object.Obj {
+	cl, ok := fn.(*object.Closure)
+	if !ok {
+		if b, ok := fn.(*object.Builtin); ok {
+			return b.Call(eh, vm.env, caller, args...)
+		}
+
+		return object.NewErr(caller, eh, true, eh.Msg("CALLBACK_NOT_CALLABLE"), fn.Inspect())
+	}
+
+	sp := vm.sp
+	if err := vm.push(cl); err != nil {
+		return object.WrapErr(err)
+	}
+
+	for _, arg := range args {
+		if err := vm.push(arg); err != nil {
+			return object.WrapErr(err)
+		}
+	}
+
+	frames := vm.framesIndex
+	if err := vm.callClosure(cl, len(args), caller); err != nil {
+		vm.sp = sp
+		return object.WrapErr(err)
+	}
+
+	if err := vm.run(frames); err != nil {
+		return object.WrapErr(err)
+	}
+
+	result := vm.pop()
+	vm.sp = sp
+	return result
+}
+
+func (vm *VM) callBuiltin(fn *object.Builtin, numArgs int, caller token.Token) error {
+	args := vm.stack[vm.sp-numArgs : vm.sp]
+	result := fn.Call(&vm.eh, vm.env, caller, args...)
+	if err := vm.env.GetRuntime().Alloc(result); err != nil {
+		return err
+	}
+	vm.sp = vm.sp - numArgs - 1
+
+	if result == nil {
+		return vm.push(Null)
+	}
+
+	if e, ok := result.(*object.Error); ok {
+		return e
+	}
+
+	return vm.push(result)
+}
+
+func (vm *VM) callClosure(cl *object.Closure, numArgs int, caller token.Token) error {
 	if numArgs != cl.Fn.NumParams {
-		return fmt.Errorf("wrong args w=%d; g=%d", cl.Fn.NumParams, numArgs)
+		return object.NewDiagErr(&vm.eh, diag.Diagnostic{
+			Msg:     vm.eh.Sprintf(vm.eh.Msg("FUN_CALL_NOT_ENOUGH_ARGS"), cl.Fn.Name, cl.Fn.NumParams, numArgs),
+			Primary: diag.At(caller, ""),
+			Hint:    fmt.Sprintf(vm.eh.Msg("FUN_PARAMS_HINT"), cl.Fn.Name, "("+strings.Join(cl.Fn.Params, ", ")+")"),
+		})
 	}
 	//fn, ok := vm.stack[vm.sp-1-numArgs].(*object.CompiledFunc)
 	//if !ok {
 	//	return fmt.Errorf("calling non-function")
 	//}
 
+	if err := vm.env.GetRuntime().CheckCallDepth(vm.framesIndex); err != nil {
+		return err
+	}
+
 	frame := NewFrame(cl, vm.sp-numArgs)
 	vm.pushFrame(frame)
 	vm.sp = frame.basePointer + cl.Fn.NumLocals
+	vm.growStack(vm.sp)
 	return nil
 }
 
@@ -291,6 +466,8 @@ func (vm *VM) exeIndexExpr(left, index object.Obj) error {
 	switch {
 	case left.Type() == object.ARRAY_OBJ && index.Type() == object.NUM_OBJ:
 		return vm.exeArrIndex(left, index)
+	case left.Type() == object.STRING_OBJ && index.Type() == object.NUM_OBJ:
+		return vm.exeStrIndex(left, index)
 	case left.Type() == object.HASH_OBJ:
 		return vm.exeHashIndex(left, index)
 	default:
@@ -298,6 +475,48 @@ func (vm *VM) exeIndexExpr(left, index object.Obj) error {
 	}
 }
 
+// exeStrIndex pushes the grapheme cluster of a string at an index
+func (vm *VM) exeStrIndex(str, index object.Obj) error {
+	i, ok := object.AsIndex(index)
+	if !ok {
+		return errors.New(vm.env.GetRuntime().Pack.Msg("INDEX_MUST_BE_NUMBER"))
+	}
+
+	if c, ok := object.ElementAt(str, i); ok {
+		return vm.push(c)
+	}
+
+	return vm.push(Null)
+}
+
+// exeSliceExpr pushes a part of an array or a string; the missing bounds
+// are null
+func (vm *VM) exeSliceExpr(left, start, end object.Obj) error {
+	if left.Type() != object.ARRAY_OBJ && left.Type() != object.STRING_OBJ {
+		return fmt.Errorf("slice operator not supported %s", left.Type())
+	}
+
+	bounds := [2]*int64{}
+	for i, b := range []object.Obj{start, end} {
+		if b.Type() == object.NULL_OBJ {
+			continue
+		}
+
+		v, ok := object.AsIndex(b)
+		if !ok {
+			return errors.New(vm.env.GetRuntime().Pack.Msg("INDEX_MUST_BE_NUMBER"))
+		}
+		bounds[i] = &v
+	}
+
+	result := object.SliceOf(left, bounds[0], bounds[1])
+	if err := vm.env.GetRuntime().Alloc(result); err != nil {
+		return err
+	}
+
+	return vm.push(result)
+}
+
 func (vm *VM) exeArrIndex(arr, index object.Obj) error {
 	arrObj := arr.(*object.Array)
 	fi, _ := index.(*object.Number).Value.GetAsFloat()
@@ -465,7 +684,12 @@ func (vm *VM) exeStrBinaryOp(op code.OpCode, l, r object.Obj) error {
 	lval := l.(*object.String).Value
 	rval := r.(*object.String).Value
 
-	return vm.push(&object.String{Value: lval + rval})
+	result := &object.String{Value: lval + rval}
+	if err := vm.env.GetRuntime().Alloc(result); err != nil {
+		return err
+	}
+
+	return vm.push(result)
 }
 
 func (vm *VM) exeNumBinaryOp(op code.OpCode, left, right object.Obj) error {
@@ -483,7 +707,6 @@ func (vm *VM) exeNumBinaryOp(op code.OpCode, left, right object.Obj) error {
 		result, _, _ = number.NumberOperation(token.MUL, lval, rval)
 	case code.OpDiv:
 		result, _, _ = number.NumberOperation(token.DIV, lval, rval)
-		fmt.Println(result)
 	default:
 		return fmt.Errorf("Unknown number operator : %d", op)
 
@@ -493,16 +716,29 @@ func (vm *VM) exeNumBinaryOp(op code.OpCode, left, right object.Obj) error {
 }
 
 func (v *VM) push(o object.Obj) error {
-	if v.sp >= StackSize {
-		return fmt.Errorf("Stack Overflow")
-	}
-
+	v.growStack(v.sp + 1)
 	v.stack[v.sp] = o
 	v.sp++
 
 	return nil
 }
 
+// growStack makes room for n values on the stack
+func (v *VM) growStack(n int) {
+	if n <= len(v.stack) {
+		return
+	}
+
+	size := 2 * len(v.stack)
+	if size < n {
+		size = n
+	}
+
+	stack := make([]object.Obj, size)
+	copy(stack, v.stack)
+	v.stack = stack
+}
+
 func (v *VM) pop() object.Obj {
 	o := v.stack[v.sp-1]
 	v.sp--
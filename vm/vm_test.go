@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	log "github.com/sirupsen/logrus"
 	"go.cs.palashbauri.in/pankti/ast"
 	"go.cs.palashbauri.in/pankti/compiler"
 	"go.cs.palashbauri.in/pankti/lexer"
@@ -13,10 +12,6 @@ import (
 	"go.cs.palashbauri.in/pankti/parser"
 )
 
-func init() {
-	log.SetLevel(log.ErrorLevel)
-}
-
 func parse(i string) *ast.Program {
 	l := lexer.NewLexer(i)
 	p := parser.NewParser(&l)
@@ -285,3 +280,20 @@ func TestNumber(t *testing.T) {
 	//t.Log(tests)
 	runVmTests(t, tests)
 }
+
+func TestBuiltinsAndModules(t *testing.T) {
+	tests := []vmTestCase{
+		{`len([1, 2, 3])`, number.MakeInt(3)},
+		{`dhori x = "abcd"
+		dhori l = ekti kaj(a) len(a) sesh
+		l(x)`, number.MakeInt(4)},
+		{`dhori g = anoyon "গণিত"
+		g.গসাগু(12, 18)`, number.MakeInt(6)},
+		{`dhori g = anoyon "গণিত"
+		g.যোগ(2, 5)`, number.MakeInt(7)},
+		{`dhori a = anoyon "array"
+		a.অন্তিম([1, 2, 3])`, number.MakeInt(3)},
+	}
+
+	runVmTests(t, tests)
+}
@@ -2,68 +2,91 @@ package main
 
 import (
 	"bytes"
-	"io/ioutil"
+	"context"
+	"io"
 	"strings"
 	"syscall/js"
+	"time"
 
-	"github.com/sirupsen/logrus"
-	"go.cs.palashbauri.in/pankti/evaluator"
-	"go.cs.palashbauri.in/pankti/lexer"
 	"go.cs.palashbauri.in/pankti/object"
-	"go.cs.palashbauri.in/pankti/parser"
+	"go.cs.palashbauri.in/pankti/pankti"
+	"go.cs.palashbauri.in/pankti/vfs"
 )
 
-func init() {
-	logrus.SetLevel(logrus.ErrorLevel)
-}
-
 func main() {
 	js.Global().Set("runner", runner())
 	js.Global().Set("evs", js.FuncOf(rfile))
 	<-make(chan bool)
 }
 
+// programs are stopped after running this long or allocating this much
+// memory, so an endless loop does not freeze the app
+const (
+	runTimeout = 10 * time.Second
+	maxAlloc   = 256 << 20
+)
+
+// DoRun runs src and returns its output. The program reads its input
+// from the lines of input; if input is nil, the user is asked for each
+// line with the prompt of the browser
+//
 //export DoRun
-func DoRun(src string) string {
+func DoRun(src string, input *string) string {
+
+	out := bytes.Buffer{}
+	opts := []pankti.Option{
+		pankti.WithStdout(&out),
+		pankti.WithStderr(&out),
+		pankti.WithLimits(object.Limits{MaxAlloc: maxAlloc}),
+		// the browser has no filesystem, so every run gets an empty one
+		// in memory
+		pankti.WithFS(vfs.NewMem()),
+		pankti.WithPermissions(object.Permissions{NoOSInfo: true}),
+	}
 
-	l := lexer.NewLexer(src)
-	p := parser.NewParser(&l)
-	prog := p.ParseProg()
+	if input != nil {
+		opts = append(opts, pankti.WithStdin(strings.NewReader(*input)))
+	} else {
+		opts = append(opts, pankti.WithInput(browserPrompt))
+	}
 
-	if len(p.GetErrors()) >= 1 {
-		tempErrs := []string{}
+	it := pankti.New(opts...)
 
-		for _, item := range p.GetErrors() {
-			tempErrs = append(tempErrs, item.String())
-		}
+	ctx, cancel := context.WithTimeout(context.Background(), runTimeout)
+	defer cancel()
+	evd, err := it.Run(ctx, src)
 
-		return strings.Join(tempErrs, " \n")
+	if err == nil && evd != nil {
+		out.WriteString(evd.Inspect())
 	}
 
-	env := object.NewEnvMap()
-	eh := object.ErrorHelper{Source: src}
-	printBuff := bytes.Buffer{}
-	evd := evaluator.Eval(prog, env, eh, &printBuff, false)
-	rd, err := ioutil.ReadAll(&printBuff)
-
-	printValue := ""
+	return out.String()
+}
 
-	if err == nil {
-		printValue = string(rd[:])
+// browserPrompt asks for a line of input with `window.prompt`
+func browserPrompt(msg string) (string, error) {
+	line := js.Global().Call("prompt", msg)
+	if line.IsNull() || line.IsUndefined() {
+		return "", io.EOF
 	}
 
-	if evd != nil {
-		return printValue + evd.Inspect()
-	} else {
-		return printValue
+	return line.String(), nil
+}
+
+// inputArg returns the optional program input given after the source
+func inputArg(args []js.Value) *string {
+	if len(args) < 2 || args[1].IsNull() || args[1].IsUndefined() {
+		return nil
 	}
 
+	input := args[1].String()
+	return &input
 }
 
 func rfile(this js.Value, args []js.Value) interface{} {
 	if len(args) >= 1 {
 		source_code := args[0].String()
-		return DoRun(source_code)
+		return DoRun(source_code, inputArg(args))
 	}
 	return ""
 }
@@ -71,13 +94,13 @@ func rfile(this js.Value, args []js.Value) interface{} {
 //export update
 func runner() js.Func {
 	runFunc := js.FuncOf(func(this js.Value, args []js.Value) any {
-		if len(args) != 1 {
+		if len(args) < 1 || len(args) > 2 {
 			return "Invalid arguments"
 		}
 
 		source_code := args[0].String()
 
-		output := DoRun(source_code)
+		output := DoRun(source_code, inputArg(args))
 
 		return output
 